@@ -16,6 +16,12 @@ func InitDB() {
 	ensureTable(db, &models.Post{})
 	ensureTable(db, &models.PostCategory{})
 	ensureTable(db, &models.PostTag{})
+	ensureTable(db, &models.PostRevision{})
+	ensureTable(db, &models.PostAutosave{})
+	ensureTable(db, &models.PostSlugHistory{})
+	ensureTable(db, &models.PreviewKey{})
+	ensureTable(db, &models.Series{})
+	ensureTable(db, &models.SeriesPost{})
 	ensureTable(db, &models.Comment{})
 	ensureTable(db, &models.Like{})
 	ensureTable(db, &models.HotData{})
@@ -26,6 +32,7 @@ func InitDB() {
 	ensureTable(db, &models.TrafficSnapshot{})
 	ensureTable(db, &models.ImageCompressStats{})
 	ensureTable(db, &models.ImageCompressJob{})
+	ensureTable(db, &models.ImportMapping{})
 }
 
 func ensureTable(db *gorm.DB, model interface{}) {
@@ -3,6 +3,7 @@ package main
 import (
 	"api/internal/config"
 	"api/internal/middleware"
+	"api/internal/modules/content/controllers"
 	"api/internal/modules/content/routes"
 	adminRoutes "api/internal/modules/content/routes/admin"
 	"api/internal/modules/media"
@@ -41,6 +42,11 @@ func InitRouter() *gin.Engine {
 	// 静态文件服务：提供上传文件的公开访问
 	r.Static("/uploads", "./uploads")
 
+	// 搜索引擎抓取入口
+	r.GET("/robots.txt", controllers.GetRobotsTxt)
+	r.GET("/sitemap.xml", controllers.GetSitemap)
+	r.GET("/sitemap/:part", controllers.GetSitemapPart)
+
 	// ========== 前端用户访问API（公开或认证用户访问）==========
 	// 保持原有接口路径不变，确保向前兼容
 	routes.RegisterUserRoutes(r)
@@ -51,9 +57,14 @@ func InitRouter() *gin.Engine {
 	routes.RegisterLikeRoutes(r)
 	routes.RegisterPageRoutes(r)
 	routes.RegisterMomentRoutes(r)
+	routes.RegisterSeriesRoutes(r)
+	routes.RegisterAuthorRoutes(r)  // 作者主页
+	routes.RegisterArchiveRoutes(r) // 按年月归档
 	routes.RegisterGuestbookRoutes(r)
 	routes.RegisterHotDataRoutes(r)
 	routes.RegisterStatsRoutes(r)
+	routes.RegisterPreviewRoutes(r) // 草稿签名预览
+	routes.RegisterFeedRoutes(r)    // RSS/Atom/JSON Feed 订阅
 	// 公开的工具类接口（例如图片压缩），不需要后台登录
 	routes.RegisterCompressRoutes(r)
 	routes.RegisterDrawGuessRoutes(r)
@@ -66,8 +77,14 @@ func InitRouter() *gin.Engine {
 	adminRoutes.RegisterAdminCommentRoutes(r)
 	adminRoutes.RegisterAdminMomentRoutes(r)
 	adminRoutes.RegisterAdminGuestbookRoutes(r)
-	adminRoutes.RegisterAdminPageRoutes(r)   // 页面管理接口
-	adminRoutes.RegisterAdminUploadRoutes(r) // 文件上传接口
+	adminRoutes.RegisterAdminPageRoutes(r)      // 页面管理接口
+	adminRoutes.RegisterAdminUploadRoutes(r)    // 文件上传接口
+	adminRoutes.RegisterAdminPreviewRoutes(r)   // 草稿预览链接
+	adminRoutes.RegisterAdminSeriesRoutes(r)    // 文章系列
+	adminRoutes.RegisterAdminTrashRoutes(r)     // 回收站
+	adminRoutes.RegisterAdminExportRoutes(r)    // 内容导出
+	adminRoutes.RegisterAdminImportRoutes(r)    // WordPress 导入
+	adminRoutes.RegisterAdminLinkCheckRoutes(r) // 失效链接检查
 
 	return r
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"api/internal/config"
 	"api/internal/modules/analytics"
+	contentService "api/internal/modules/content/service"
 	"fmt"
 )
 
@@ -13,6 +14,15 @@ func Run() {
 	if cfg.AnalyticsEnabled {
 		analytics.StartETLWorker(cfg.AnalyticsETL)
 	}
+	if cfg.ScheduledPublishEnabled {
+		contentService.StartScheduledPublisher(cfg.ScheduledPublishInterval)
+	}
+	if cfg.TrashRetentionDays > 0 {
+		contentService.StartTrashPurger(cfg.TrashPurgeInterval, cfg.TrashRetentionDays)
+	}
+	if cfg.RelatedPostsEnabled {
+		contentService.StartRelatedPostsWorker(cfg.RelatedPostsWarmInterval)
+	}
 	r := InitRouter()
 	_ = r.Run(fmt.Sprintf(":%s", cfg.HTTPPort))
 }
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"api/internal/modules/content/service"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	var (
+		external    = flag.Bool("external", false, "同时检查外部链接")
+		endpoint    = flag.String("endpoint", "", "外部链接请求改发到该地址（如本地桩服务 http://127.0.0.1:9000），保留原路径与查询参数")
+		timeout     = flag.Duration("timeout", 10*time.Second, "单个外部链接的请求超时")
+		concurrency = flag.Int("concurrency", 8, "外部链接并发数")
+		reportPath  = flag.String("report", "", "将完整报告以 JSON 写入该文件")
+	)
+	flag.Parse()
+
+	report, err := service.CheckContentLinks(context.Background(), service.LinkCheckOptions{
+		External:    *external,
+		Checker:     service.NewHTTPLinkChecker(*timeout, *endpoint),
+		Concurrency: *concurrency,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, issue := range report.Broken {
+		fmt.Printf("[%s#%d] %s -> %s (%s)\n", issue.SourceType, issue.SourceID, issue.SourceTitle, issue.URL, issue.Reason)
+	}
+	fmt.Printf("扫描文章: %d\n", report.Posts)
+	fmt.Printf("扫描页面: %d\n", report.Pages)
+	fmt.Printf("扫描动态: %d\n", report.Moments)
+	fmt.Printf("检查引用: %d（外部地址 %d）\n", report.Links, report.External)
+	fmt.Printf("失效引用: %d\n", len(report.Broken))
+
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*reportPath, data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if len(report.Broken) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"api/internal/modules/content/service"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	out := flag.String("out", "blog-export-"+time.Now().Format("20060102-150405")+".zip", "导出的 zip 文件路径")
+	flag.Parse()
+
+	report, err := service.ExportContent(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, ref := range report.MissingFiles {
+		fmt.Printf("未找到文件: %s\n", ref)
+	}
+	fmt.Printf("导出文章: %d\n", report.Posts)
+	fmt.Printf("导出页面: %d\n", report.Pages)
+	fmt.Printf("导出动态: %d\n", report.Moments)
+	fmt.Printf("打包文件: %d\n", report.Files)
+	fmt.Printf("已写入: %s\n", report.Path)
+}
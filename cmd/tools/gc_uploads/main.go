@@ -0,0 +1,103 @@
+package main
+
+import (
+	"api/internal/modules/content/service"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	var (
+		action     = flag.String("action", "list", "操作：list 列出孤立文件，quarantine 移入隔离目录，purge 彻底删除过期的隔离批次，restore 恢复隔离的文件")
+		apply      = flag.Bool("apply", false, "实际执行；默认只试运行并输出将要处理的文件")
+		force      = flag.Bool("force", false, "数据库中没有任何上传文件引用时仍然继续（默认拒绝，防止连错数据库误删全部文件）")
+		minAge     = flag.Duration("min-age", 7*24*time.Hour, "只处理最后修改时间早于该时长的文件，避免误删刚上传、尚未保存的文件")
+		keep       = flag.Duration("keep", 7*24*time.Hour, "隔离批次保留时长，超过后 purge 才会删除")
+		dir        = flag.String("dir", service.DefaultUploadQuarantineDir, "隔离目录")
+		batch      = flag.String("batch", "", "restore 时只恢复指定批次（如 20240101-120000）")
+		reportPath = flag.String("report", "", "将结果以 JSON 写入该文件")
+	)
+	flag.Parse()
+
+	// 上传文件引用依赖 API_BASE_URL 区分站内地址，未配置时使用的默认值会让引用判断出错
+	if strings.TrimSpace(os.Getenv("API_BASE_URL")) == "" {
+		log.Fatal("未设置 API_BASE_URL，拒绝运行：请设置为站点实际使用的 API 地址")
+	}
+
+	opts := service.UploadGCOptions{
+		QuarantineDir: *dir,
+		MinAge:        *minAge,
+		Keep:          *keep,
+		Batch:         *batch,
+		Apply:         *apply,
+		Force:         *force,
+	}
+
+	var (
+		report interface{}
+		err    error
+	)
+	switch *action {
+	case "list":
+		var files []service.UploadFile
+		files, err = service.ListOrphanUploads(*minAge)
+		if err == nil {
+			var size int64
+			for _, file := range files {
+				fmt.Printf("%s\t%d 字节\t%d 天\n", file.Path, file.Size, file.AgeDays)
+				size += file.Size
+			}
+			fmt.Printf("孤立文件: %d（%d 字节）\n", len(files), size)
+			report = files
+		}
+	case "quarantine":
+		report, err = runGC(service.QuarantineOrphanUploads, opts, "隔离")
+	case "purge":
+		report, err = runGC(service.PurgeUploadQuarantine, opts, "删除")
+	case "restore":
+		report, err = runGC(service.RestoreUploadQuarantine, opts, "恢复")
+	default:
+		log.Fatalf("未知操作: %s", *action)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*reportPath, data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func runGC(fn func(service.UploadGCOptions) (*service.UploadGCReport, error), opts service.UploadGCOptions, verb string) (*service.UploadGCReport, error) {
+	report, err := fn(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range report.Files {
+		fmt.Printf("%s\t%d 字节\n", file.Path, file.Size)
+	}
+	for _, p := range report.Restored {
+		fmt.Printf("重新被引用，移回上传目录: %s\n", p)
+	}
+	for _, msg := range report.Failed {
+		fmt.Printf("失败: %s\n", msg)
+	}
+	fmt.Printf("批次: %v\n", report.Batches)
+	if report.Applied {
+		fmt.Printf("已%s: %d 个文件（%d 字节）\n", verb, len(report.Files), report.Bytes)
+	} else {
+		fmt.Printf("试运行，将%s: %d 个文件（%d 字节），确认后加 -apply 执行\n", verb, len(report.Files), report.Bytes)
+	}
+	return report, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"api/internal/modules/content/service"
+	"api/internal/modules/media"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	var (
+		dir        = flag.String("dir", "", "Markdown 文件目录（递归扫描 .md/.markdown）")
+		authorID   = flag.Uint64("author", 1, "导入文章的作者用户ID")
+		staticDir  = flag.String("static-dir", "", "以 / 开头的图片所在的静态目录，例如 Hugo 的 static/ 或 Hexo 的 source/")
+		imageDir   = flag.String("image-dir", media.ImageUploadDir, "图片复制目标目录")
+		dryRun     = flag.Bool("dry-run", false, "只输出导入报告，不写数据库、不复制图片")
+		reportPath = flag.String("report", "", "将完整报告以 JSON 写入该文件")
+	)
+	flag.Parse()
+
+	if *dir == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	report, err := service.ImportMarkdownDir(service.MarkdownImportOptions{
+		Dir:       *dir,
+		AuthorID:  *authorID,
+		StaticDir: *staticDir,
+		ImageDir:  *imageDir,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, item := range report.Items {
+		switch item.Action {
+		case service.ImportActionCreate:
+			fmt.Printf("[%s] %s -> %s（%s，分类: %s，标签: %s，图片: %d）\n", item.Action, item.File, strings.TrimSuffix(item.Type+":"+item.Slug, ":"), item.Status,
+				strings.Join(item.Categories, "/"), strings.Join(item.Tags, "/"), item.Images)
+		default:
+			fmt.Printf("[%s] %s: %s\n", item.Action, item.File, item.Message)
+		}
+		for _, ref := range item.MissingImages {
+			fmt.Printf("    未找到图片: %s\n", ref)
+		}
+	}
+
+	if report.DryRun {
+		fmt.Println("\n试运行，未写入任何数据")
+	}
+	fmt.Printf("扫描文件: %d\n", report.ScannedFiles)
+	fmt.Printf("导入内容: %d\n", report.Created)
+	fmt.Printf("跳过文件: %d\n", report.Skipped)
+	fmt.Printf("失败文件: %d\n", report.Failed)
+	fmt.Printf("复制图片/文件: %d\n", report.CopiedImages)
+	fmt.Printf("新建分类: %d %s\n", len(report.CreatedCategories), strings.Join(report.CreatedCategories, ", "))
+	fmt.Printf("新建标签: %d %s\n", len(report.CreatedTags), strings.Join(report.CreatedTags, ", "))
+
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*reportPath, data, 0644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("报告已写入: %s\n", *reportPath)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"api/internal/modules/content/service"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	var (
+		file       = flag.String("file", "", "WordPress 导出的 WXR 文件（工具 → 导出）")
+		authorID   = flag.Uint64("author", 1, "原作者在本站找不到同名用户时使用的作者用户ID")
+		uploadsDir = flag.String("uploads-dir", "", "本地的 wp-content/uploads 目录，附件优先从这里复制")
+		download   = flag.Bool("download", false, "本地找不到附件时从原站下载")
+		hosts      = flag.String("download-hosts", "", "允许下载附件的主机，逗号分隔；默认只允许 WXR 中 base_site_url 的主机（如附件在 CDN 上需显式指定）")
+		dryRun     = flag.Bool("dry-run", false, "只输出导入报告，不写数据库、不复制文件")
+		reportPath = flag.String("report", "", "将完整报告以 JSON 写入该文件")
+	)
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var downloadHosts []string
+	if *hosts != "" {
+		downloadHosts = strings.Split(*hosts, ",")
+	}
+	report, err := service.ImportWXR(f, service.WXRImportOptions{
+		AuthorID:      *authorID,
+		UploadsDir:    *uploadsDir,
+		Download:      *download,
+		DownloadHosts: downloadHosts,
+		DryRun:        *dryRun,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, msg := range report.Errors {
+		fmt.Printf("[error] %s\n", msg)
+	}
+	if report.DryRun {
+		fmt.Println("\n试运行，未写入任何数据")
+	}
+	fmt.Printf("来源站点: %s\n", report.Source)
+	for _, row := range []struct {
+		name   string
+		counts service.WXRImportCounts
+	}{
+		{"分类", report.Categories},
+		{"标签", report.Tags},
+		{"附件", report.Attachments},
+		{"文章", report.Posts},
+		{"页面", report.Pages},
+		{"评论", report.Comments},
+	} {
+		fmt.Printf("%s: 新建 %d，跳过 %d，失败 %d\n", row.name, row.counts.Created, row.counts.Skipped, row.counts.Failed)
+	}
+
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*reportPath, data, 0644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("报告已写入: %s\n", *reportPath)
+	}
+}
@@ -0,0 +1,11 @@
+-- 编辑冲突检测：文章、页面、动态增加版本号，每次保存加一，后台更新时通过 If-Match 校验
+-- 执行前请先备份数据库
+
+ALTER TABLE posts
+    ADD COLUMN version BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '版本号(乐观锁)';
+
+ALTER TABLE pages
+    ADD COLUMN version BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '版本号(乐观锁)';
+
+ALTER TABLE moments
+    ADD COLUMN version BIGINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '版本号(乐观锁)';
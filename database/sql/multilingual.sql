@@ -0,0 +1,15 @@
+-- 多语言：为文章和页面增加语言代码与翻译组，同一翻译组中的文章互为不同语言版本
+-- 已有内容的语言设为 zh-CN，站点默认语言（SITE_LANG）不同时请同步修改下面的默认值
+-- 执行前请先备份数据库
+
+ALTER TABLE posts
+    ADD COLUMN lang VARCHAR(16) NOT NULL DEFAULT 'zh-CN' COMMENT '语言代码(BCP 47)',
+    ADD COLUMN translation_group VARCHAR(36) NULL COMMENT '翻译组标识',
+    ADD INDEX idx_posts_lang (lang),
+    ADD INDEX idx_posts_translation_group (translation_group);
+
+ALTER TABLE pages
+    ADD COLUMN lang VARCHAR(16) NOT NULL DEFAULT 'zh-CN' COMMENT '语言代码(BCP 47)',
+    ADD COLUMN translation_group VARCHAR(36) NULL COMMENT '翻译组标识',
+    ADD INDEX idx_pages_lang (lang),
+    ADD INDEX idx_pages_translation_group (translation_group);
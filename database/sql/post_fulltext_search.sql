@@ -0,0 +1,7 @@
+-- 文章全文检索索引
+-- 使用 ngram 分词器（默认 ngram_token_size=2），同时支持中文与英文检索
+-- 新库由 GORM 建表时自动创建；已有库需手动执行本脚本，执行后重启服务生效
+-- 执行前请先备份数据库，大表建索引期间会占用较多 IO
+
+ALTER TABLE posts
+    ADD FULLTEXT INDEX idx_posts_fulltext (title, excerpt, content) WITH PARSER ngram;
@@ -0,0 +1,13 @@
+-- 定时发布：为文章和动态增加 scheduled 状态
+-- scheduled 状态下 published_at 表示计划发布时间，由后台定时任务到期后改为 published
+-- 执行前请先备份数据库
+
+ALTER TABLE posts
+    MODIFY COLUMN status ENUM('published','draft','pending','scheduled','trash') DEFAULT 'draft' COMMENT '状态';
+
+ALTER TABLE moments
+    MODIFY COLUMN status ENUM('published','draft','scheduled') DEFAULT 'draft' COMMENT '状态';
+
+-- 定时任务按 status + published_at 查找到期内容
+CREATE INDEX idx_posts_status_published_at ON posts(status, published_at);
+CREATE INDEX idx_moments_status_published_at ON moments(status, published_at);
@@ -0,0 +1,17 @@
+-- SEO 元数据：为文章和页面增加 meta 标题/描述、规范链接、分享图片与禁止收录标记
+-- 留空的字段由接口自动回退到标题、摘要与封面图
+-- 执行前请先备份数据库
+
+ALTER TABLE posts
+    ADD COLUMN meta_title VARCHAR(200) NULL COMMENT 'SEO标题',
+    ADD COLUMN meta_description VARCHAR(500) NULL COMMENT 'SEO描述',
+    ADD COLUMN canonical_url VARCHAR(255) NULL COMMENT '规范链接',
+    ADD COLUMN og_image VARCHAR(255) NULL COMMENT '分享图片URL',
+    ADD COLUMN noindex TINYINT(1) NOT NULL DEFAULT 0 COMMENT '禁止搜索引擎收录';
+
+ALTER TABLE pages
+    ADD COLUMN meta_title VARCHAR(200) NULL COMMENT 'SEO标题',
+    ADD COLUMN meta_description VARCHAR(500) NULL COMMENT 'SEO描述',
+    ADD COLUMN canonical_url VARCHAR(255) NULL COMMENT '规范链接',
+    ADD COLUMN og_image VARCHAR(255) NULL COMMENT '分享图片URL',
+    ADD COLUMN noindex TINYINT(1) NOT NULL DEFAULT 0 COMMENT '禁止搜索引擎收录';
@@ -0,0 +1,12 @@
+-- 回收站：删除文章、评论时改为 trash 状态，记录移入时间与原状态，用于恢复和按保留天数自动清理
+-- 执行前请先备份数据库
+
+ALTER TABLE posts
+    ADD COLUMN trashed_at DATETIME(3) NULL COMMENT '移入回收站时间',
+    ADD COLUMN trashed_from VARCHAR(20) NULL COMMENT '移入回收站前的状态',
+    ADD INDEX idx_posts_trashed_at (trashed_at);
+
+ALTER TABLE comments
+    ADD COLUMN trashed_at DATETIME(3) NULL COMMENT '移入回收站时间',
+    ADD COLUMN trashed_from VARCHAR(20) NULL COMMENT '移入回收站前的状态',
+    ADD INDEX idx_comments_trashed_at (trashed_at);
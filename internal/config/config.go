@@ -38,6 +38,38 @@ type AppConfig struct {
 	RedisPassword string
 	RedisDB       int
 	RedisEnabled  bool
+
+	PostRevisionLimit int
+	AutosaveTTL       time.Duration
+
+	ScheduledPublishEnabled  bool
+	ScheduledPublishInterval time.Duration
+
+	TrashRetentionDays int
+	TrashPurgeInterval time.Duration
+
+	PostAccessSecret   string
+	PostAccessTokenTTL time.Duration
+
+	PreviewSecret string
+	PreviewTTL    time.Duration
+
+	RelatedPostsEnabled      bool
+	RelatedPostsWarmInterval time.Duration
+
+	SiteTitle       string
+	SiteDescription string
+	SiteLang        string
+	FeedFullContent bool
+	FeedItemLimit   int
+	FeedCacheTTL    time.Duration
+
+	SitemapCacheTTL time.Duration
+	RobotsTxtFile   string
+	RobotsDisallow  string
+
+	LinkCheckTimeout  time.Duration
+	LinkCheckEndpoint string
 }
 
 var (
@@ -76,6 +108,38 @@ func Load() AppConfig {
 			RedisPassword: envString("REDIS_PASSWORD", ""),
 			RedisDB:       envInt("REDIS_DB", 0),
 			RedisEnabled:  envBool("ENABLE_REDIS", true),
+
+			PostRevisionLimit: envInt("POST_REVISION_LIMIT", 50),
+			AutosaveTTL:       envDuration("AUTOSAVE_TTL", 7*24*time.Hour),
+
+			ScheduledPublishEnabled:  envBool("ENABLE_SCHEDULED_PUBLISH", true),
+			ScheduledPublishInterval: envDuration("SCHEDULED_PUBLISH_INTERVAL", time.Minute),
+
+			TrashRetentionDays: envInt("TRASH_RETENTION_DAYS", 30),
+			TrashPurgeInterval: envDuration("TRASH_PURGE_INTERVAL", time.Hour),
+
+			PostAccessSecret:   envString("POST_ACCESS_SECRET", ""),
+			PostAccessTokenTTL: envDuration("POST_ACCESS_TOKEN_TTL", 2*time.Hour),
+
+			PreviewSecret: envString("PREVIEW_SECRET", ""),
+			PreviewTTL:    envDuration("PREVIEW_TTL", 72*time.Hour),
+
+			RelatedPostsEnabled:      envBool("ENABLE_RELATED_POSTS_PRECOMPUTE", true),
+			RelatedPostsWarmInterval: envDuration("RELATED_POSTS_WARM_INTERVAL", 5*time.Minute),
+
+			SiteTitle:       envString("SITE_TITLE", "Blog"),
+			SiteDescription: envString("SITE_DESCRIPTION", ""),
+			SiteLang:        envString("SITE_LANG", "zh-CN"),
+			FeedFullContent: envBool("FEED_FULL_CONTENT", false),
+			FeedItemLimit:   envInt("FEED_ITEM_LIMIT", 20),
+			FeedCacheTTL:    envDuration("FEED_CACHE_TTL", 10*time.Minute),
+
+			SitemapCacheTTL: envDuration("SITEMAP_CACHE_TTL", time.Hour),
+			RobotsTxtFile:   envString("ROBOTS_TXT_FILE", ""),
+			RobotsDisallow:  envString("ROBOTS_DISALLOW", "/api/admin/,/api/preview/"),
+
+			LinkCheckTimeout:  envDuration("LINK_CHECK_TIMEOUT", 10*time.Second),
+			LinkCheckEndpoint: envString("LINK_CHECK_ENDPOINT", ""),
 		}
 	})
 	return cfg
@@ -28,6 +28,8 @@ func CORSMiddleware() gin.HandlerFunc {
 			"Authorization",
 			"Accept",
 			"X-Requested-With",
+			"If-Match",
+			"X-Post-Token", // 密码文章的解锁令牌
 		},
 
 		// 暴露的响应头
@@ -35,6 +37,7 @@ func CORSMiddleware() gin.HandlerFunc {
 			"Content-Length",
 			"Content-Type",
 			"Authorization",
+			"ETag",
 		},
 
 		// 允许携带凭证（如cookies）
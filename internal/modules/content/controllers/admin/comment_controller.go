@@ -14,6 +14,7 @@ func ListComments(c *gin.Context) {
 	var req struct {
 		Page     int    `form:"page"`
 		PageSize int    `form:"page_size"`
+		Cursor   string `form:"cursor"`  // 可选：游标分页，第一页传空值
 		PostID   uint64 `form:"post_id"` // 可选：筛选特定文章
 		Status   string `form:"status"`  // 可选：筛选状态 approved/pending/spam/trash
 		Q        string `form:"q"`       // 可选：搜索关键词
@@ -25,6 +26,16 @@ func ListComments(c *gin.Context) {
 		return
 	}
 
+	if _, ok := c.GetQuery("cursor"); ok {
+		result, err := service.ListCommentsByCursor(req.Cursor, req.PageSize, req.PostID, req.Status, req.Q, req.Sort)
+		if err != nil {
+			respondCursorError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
 	// 使用分页服务
 	result, err := service.ListCommentsWithPagination(
 		req.Page,
@@ -114,11 +125,13 @@ func UpdateCommentStatus(c *gin.Context) {
 		return
 	}
 
-	comment.Status = req.Status
-	if err = service.UpdateComment(comment); err != nil {
+	if err = service.UpdateCommentsStatus([]uint64{comment.ID}, req.Status); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "更新失败: " + err.Error()})
 		return
 	}
+	if updated, err := service.GetCommentByID(id); err == nil {
+		comment = updated
+	}
 	c.JSON(http.StatusOK, gin.H{"comment": comment})
 }
 
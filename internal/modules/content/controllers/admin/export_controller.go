@@ -0,0 +1,22 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 导出全部文章、页面、动态为 Markdown（带 front matter）及引用的上传文件，打包为 zip 下载
+// GET /api/admin/export
+func ExportContent(c *gin.Context) {
+	report, err := service.ExportContent("")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "导出失败: " + err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.FileAttachment(report.Path, "blog-export-"+time.Now().Format("20060102-150405")+".zip")
+}
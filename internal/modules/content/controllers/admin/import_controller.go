@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 上传 WordPress 导出的 WXR 文件并导入
+// POST /api/admin/import/wxr  multipart: file, dry_run=true 只返回报告
+// 附件只从服务器本地查找；从原站下载附件需要访问外部网络，只能通过 import_wxr 命令行工具执行
+func ImportWXR(c *gin.Context) {
+	const maxSize = 100 << 20 // 100MB
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "文件上传失败: " + err.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "读取文件失败: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	if download, _ := strconv.ParseBool(c.PostForm("download")); download {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "后台导入不支持下载附件，请使用 import_wxr 命令行工具的 -download 参数"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+	report, err := service.ImportWXR(file, service.WXRImportOptions{
+		AuthorID: userID.(uint64),
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrWXRInvalid) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "导入失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
@@ -0,0 +1,21 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 检查文章、页面、动态中失效的站内链接与上传文件引用
+// GET /api/admin/links/check?external=true（同时检查外部链接，耗时较长）
+func CheckContentLinks(c *gin.Context) {
+	report, err := service.CheckContentLinks(c.Request.Context(), service.LinkCheckOptions{
+		External: c.Query("external") == "true",
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "检查失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -3,6 +3,7 @@ package admin
 import (
 	"api/internal/modules/content/models"
 	"api/internal/modules/content/service"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -26,15 +27,17 @@ func GetMoment(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "动态不存在"})
 		return
 	}
+	setVersionETag(c, moment.Version)
 	c.JSON(http.StatusOK, gin.H{"moment": moment})
 }
 
 func CreateMoment(c *gin.Context) {
 	var req struct {
-		Content string   `json:"content" binding:"required"`
-		Images  []string `json:"images"`
-		Mood    string   `json:"mood"`
-		Status  string   `json:"status"`
+		Content     string   `json:"content" binding:"required"`
+		Images      []string `json:"images"`
+		Mood        string   `json:"mood"`
+		Status      string   `json:"status"`
+		PublishedAt string   `json:"published_at"` // 定时发布时间，status=scheduled 时必填
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "参数格式错误: " + err.Error()})
@@ -52,18 +55,32 @@ func CreateMoment(c *gin.Context) {
 		status = "draft"
 	}
 
+	publishAt, err := parsePublishTime(req.PublishedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	moment := &models.Moment{
 		Content: req.Content,
 		Images:  req.Images,
 		Mood:    strings.TrimSpace(req.Mood),
 		Status:  status,
 	}
+	if status == service.StatusScheduled {
+		moment.PublishedAt = publishAt
+	}
 
 	if err := service.CreateMoment(moment); err != nil {
+		if errors.Is(err, service.ErrInvalidSchedule) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "创建失败: " + err.Error()})
 		return
 	}
 
+	setVersionETag(c, moment.Version)
 	c.JSON(http.StatusOK, gin.H{"moment": moment})
 }
 
@@ -74,12 +91,16 @@ func UpdateMoment(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "动态不存在"})
 		return
 	}
+	if !checkIfMatch(c, moment.Version, "moment", moment) {
+		return
+	}
 
 	var req struct {
-		Content string   `json:"content"`
-		Images  []string `json:"images"`
-		Mood    string   `json:"mood"`
-		Status  string   `json:"status"`
+		Content     string   `json:"content"`
+		Images      []string `json:"images"`
+		Mood        string   `json:"mood"`
+		Status      string   `json:"status"`
+		PublishedAt string   `json:"published_at"` // 定时发布时间，status=scheduled 时必填
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "参数格式错误: " + err.Error()})
@@ -95,15 +116,38 @@ func UpdateMoment(c *gin.Context) {
 	if req.Mood != "" || c.GetHeader("Content-Type") == "application/json" {
 		moment.Mood = strings.TrimSpace(req.Mood)
 	}
+	publishAt, err := parsePublishTime(req.PublishedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if req.Status != "" {
+		// 定时动态被直接发布时，以当前时间作为发布时间
+		if moment.Status == service.StatusScheduled && req.Status == "published" {
+			moment.PublishedAt = nil
+		}
 		moment.Status = req.Status
 	}
+	if publishAt != nil && moment.Status == service.StatusScheduled {
+		moment.PublishedAt = publishAt
+	}
 
 	if err := service.UpdateMoment(moment); err != nil {
+		if errors.Is(err, service.ErrInvalidSchedule) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrVersionConflict) {
+			if latest, err := service.GetMomentByID(id); err == nil {
+				respondVersionConflict(c, latest.Version, "moment", latest)
+				return
+			}
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "更新失败: " + err.Error()})
 		return
 	}
 
+	setVersionETag(c, moment.Version)
 	c.JSON(http.StatusOK, gin.H{"moment": moment})
 }
 
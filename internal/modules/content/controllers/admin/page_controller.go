@@ -3,6 +3,7 @@ package admin
 import (
 	"api/internal/modules/content/models"
 	"api/internal/modules/content/service"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -17,6 +18,8 @@ func CreatePage(c *gin.Context) {
 		Content string `json:"content" binding:"required"`
 		Excerpt string `json:"excerpt"`
 		Status  string `json:"status"`
+		seoFields
+		translationFields
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "参数格式错误: " + err.Error()})
@@ -36,12 +39,26 @@ func CreatePage(c *gin.Context) {
 		Excerpt: req.Excerpt,
 		Status:  status,
 	}
+	if _, err := req.seoFields.apply(&page.SEOMeta); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	joinGroup, err := service.ApplyPageTranslation(page, req.translationFields.input())
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
 
-	if err := service.CreatePage(page); err != nil {
+	if err := service.CreatePage(page, joinGroup); err != nil {
+		if isTranslationError(err) {
+			respondTranslationError(c, err)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "创建失败: " + err.Error()})
 		return
 	}
 
+	setVersionETag(c, page.Version)
 	c.JSON(http.StatusOK, gin.H{"page": page})
 }
 
@@ -53,7 +70,9 @@ func GetPage(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "页面不存在"})
 		return
 	}
+	service.AttachPageTranslations(page, false)
 
+	setVersionETag(c, page.Version)
 	c.JSON(http.StatusOK, gin.H{"page": page})
 }
 
@@ -65,6 +84,9 @@ func UpdatePage(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "页面不存在"})
 		return
 	}
+	if !checkIfMatch(c, page.Version, "page", page) {
+		return
+	}
 
 	var req struct {
 		Title   string `json:"title"`
@@ -72,6 +94,8 @@ func UpdatePage(c *gin.Context) {
 		Content string `json:"content"`
 		Excerpt string `json:"excerpt"`
 		Status  string `json:"status"`
+		seoFields
+		translationFields
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "参数格式错误: " + err.Error()})
@@ -94,12 +118,35 @@ func UpdatePage(c *gin.Context) {
 	if req.Status != "" {
 		page.Status = req.Status
 	}
-
-	if err = service.UpdatePage(page); err != nil {
+	if _, err = req.seoFields.apply(&page.SEOMeta); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var joinGroup service.ContentWrite
+	if req.translationFields.submitted() {
+		if joinGroup, err = service.ApplyPageTranslation(page, req.translationFields.input()); err != nil {
+			respondTranslationError(c, err)
+			return
+		}
+	}
+
+	if err = service.UpdatePage(page, joinGroup); err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			if latest, err := service.GetPageByID(id); err == nil {
+				respondVersionConflict(c, latest.Version, "page", latest)
+				return
+			}
+		}
+		if isTranslationError(err) {
+			respondTranslationError(c, err)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "更新失败: " + err.Error()})
 		return
 	}
+	service.AttachPageTranslations(page, false)
 
+	setVersionETag(c, page.Version)
 	c.JSON(http.StatusOK, gin.H{"page": page})
 }
 
@@ -0,0 +1,115 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"api/internal/modules/media"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 自动保存：编辑器定时把未保存的内容写入缓冲区，浏览器崩溃后可以找回。
+// /posts/:id/autosave 对应已有文章（需有编辑权限），/posts/autosave 对应尚未保存的新文章；
+// 缓冲区按用户隔离，文章正式保存后清除，超过 AUTOSAVE_TTL 自动过期
+
+// 保存自动保存内容
+// PUT /api/admin/posts/:id/autosave、PUT /api/admin/posts/autosave
+func SavePostAutosave(c *gin.Context) {
+	postID, ok := autosavePostID(c)
+	if !ok {
+		return
+	}
+	var req struct {
+		Title       string `json:"title"`
+		Excerpt     string `json:"excerpt"`
+		Content     string `json:"content"`
+		CoverImage  string `json:"cover_image"`
+		BaseVersion uint64 `json:"base_version"` // 开始编辑时的文章版本号（即 ETag），新文章可不传
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数格式错误: " + err.Error()})
+		return
+	}
+	coverImage := req.CoverImage
+	if coverImage != "" {
+		coverImage = media.GetFullFileURL(coverImage)
+	}
+
+	autosave, err := service.SavePostAutosave(currentUserID(c), postID, service.AutosaveInput{
+		Title:       req.Title,
+		Excerpt:     req.Excerpt,
+		Content:     req.Content,
+		CoverImage:  coverImage,
+		BaseVersion: req.BaseVersion,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "自动保存失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"autosave": autosave})
+}
+
+// 获取最近一次自动保存的内容；已有文章同时返回与已保存内容的对比
+// GET /api/admin/posts/:id/autosave、GET /api/admin/posts/autosave
+func GetPostAutosave(c *gin.Context) {
+	postID, ok := autosavePostID(c)
+	if !ok {
+		return
+	}
+	autosave, err := service.GetPostAutosave(currentUserID(c), postID)
+	if err != nil {
+		if errors.Is(err, service.ErrAutosaveNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+		}
+		return
+	}
+
+	response := gin.H{"autosave": autosave}
+	if postID != 0 {
+		post, err := service.GetPostByID(postID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+			return
+		}
+		response["comparison"] = service.ComparePostAutosave(autosave, post)
+		setVersionETag(c, post.Version)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// 丢弃自动保存的内容
+// DELETE /api/admin/posts/:id/autosave、DELETE /api/admin/posts/autosave
+func DiscardPostAutosave(c *gin.Context) {
+	postID, ok := autosavePostID(c)
+	if !ok {
+		return
+	}
+	if err := service.DiscardPostAutosave(currentUserID(c), postID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "删除失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "已丢弃自动保存的内容"})
+}
+
+// autosavePostID 解析路由中的文章ID并校验编辑权限，新文章路由返回 0
+func autosavePostID(c *gin.Context) (uint64, bool) {
+	raw := c.Param("id")
+	if raw == "" {
+		return 0, true
+	}
+	id, _ := strconv.ParseUint(raw, 10, 64)
+	if _, ok := loadOwnedPost(c, id); !ok {
+		return 0, false
+	}
+	return id, true
+}
+
+func currentUserID(c *gin.Context) uint64 {
+	userID, _ := c.Get("user_id")
+	id, _ := userID.(uint64)
+	return id
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 批量操作文章（管理后台）
+// POST /api/admin/posts/batch
+// action: status（配合 status、published_at）、trash、add_tags/remove_tags（tag_ids）、
+// add_categories/remove_categories（category_ids）、author（author_id，仅管理员）
+func BatchPosts(c *gin.Context) {
+	var req struct {
+		IDs         []uint64 `json:"ids" binding:"required"`
+		Action      string   `json:"action" binding:"required"`
+		Status      string   `json:"status"`
+		PublishedAt string   `json:"published_at"`
+		CategoryIDs []uint64 `json:"category_ids"`
+		TagIDs      []uint64 `json:"tag_ids"`
+		AuthorID    uint64   `json:"author_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	publishAt, err := parsePublishTime(req.PublishedAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	editorID, _ := userID.(uint64)
+	report, err := service.BatchUpdatePosts(service.PostBatchRequest{
+		IDs:         req.IDs,
+		Action:      req.Action,
+		Status:      req.Status,
+		PublishAt:   publishAt,
+		CategoryIDs: req.CategoryIDs,
+		TagIDs:      req.TagIDs,
+		AuthorID:    req.AuthorID,
+	}, editorID, c.GetString("user_role"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPostBatch) || errors.Is(err, service.ErrPostBatchTooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "批量操作失败: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
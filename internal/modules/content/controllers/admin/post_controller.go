@@ -4,6 +4,7 @@ import (
 	"api/internal/modules/content/models"
 	"api/internal/modules/content/service"
 	"api/internal/modules/media"
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"net/http"
@@ -22,9 +23,11 @@ import (
 func CreatePost(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 
-	var title, content, excerpt, status string
+	var title, content, excerpt, status, publishedAtRaw, visibility, password string
 	var categoryIDs, tagIDs []uint64
 	var coverImageURL string
+	var seo seoFields
+	var translation translationFields
 
 	contentType := c.GetHeader("Content-Type")
 	isFormData := contentType != "" && (strings.Contains(contentType, "multipart/form-data") || strings.Contains(contentType, "application/x-www-form-urlencoded"))
@@ -35,6 +38,20 @@ func CreatePost(c *gin.Context) {
 		content = c.PostForm("content")
 		excerpt = c.PostForm("excerpt")
 		status = c.PostForm("status")
+		publishedAtRaw = c.PostForm("published_at")
+		visibility = c.PostForm("visibility")
+		password = c.PostForm("password")
+
+		formSEO, err := seoFieldsFromForm(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		seo = formSEO
+		if translation, err = translationFieldsFromForm(c); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
 		// 处理图片文件
 		if file, err := c.FormFile("image"); err == nil {
@@ -64,8 +81,13 @@ func CreatePost(c *gin.Context) {
 			Excerpt     string   `json:"excerpt"`
 			CoverImage  string   `json:"cover_image"`
 			Status      string   `json:"status"`
+			PublishedAt string   `json:"published_at"` // 定时发布时间，status=scheduled 时必填
+			Visibility  string   `json:"visibility"`   // public/private/password
+			Password    string   `json:"password"`     // visibility=password 时的访问密码，留空则保留原密码
 			CategoryIDs []uint64 `json:"category_ids"` // 前端使用category_ids
 			TagIDs      []uint64 `json:"tag_ids"`      // 前端使用tag_ids
+			seoFields
+			translationFields
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "参数格式错误: " + err.Error()})
@@ -75,6 +97,11 @@ func CreatePost(c *gin.Context) {
 		content = req.Content
 		excerpt = req.Excerpt
 		status = req.Status
+		publishedAtRaw = req.PublishedAt
+		visibility = req.Visibility
+		password = req.Password
+		seo = req.seoFields
+		translation = req.translationFields
 		// 处理cover_image：转换为完整URL
 		if req.CoverImage != "" {
 			coverImageURL = media.GetFullFileURL(req.CoverImage)
@@ -98,35 +125,56 @@ func CreatePost(c *gin.Context) {
 		status = "draft"
 	}
 
-	// 如果状态是published，设置发布时间
-	var publishedAt *time.Time
-	if status == "published" {
-		now := time.Now()
-		publishedAt = &now
+	publishAt, err := parsePublishTime(publishedAtRaw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	post := &models.Post{
-		Title:       title,
-		Slug:        slug,
-		Content:     content,
-		Excerpt:     excerpt,
-		CoverImage:  coverImageURL,
-		AuthorID:    userID.(uint64),
-		Status:      status,
-		Visibility:  "public",
-		PublishedAt: publishedAt,
-	}
-
-	if err := service.CreatePost(post, categoryIDs, tagIDs); err != nil {
+		Title:      title,
+		Slug:       slug,
+		Content:    content,
+		Excerpt:    excerpt,
+		CoverImage: coverImageURL,
+		AuthorID:   userID.(uint64),
+	}
+	if err := service.ApplyPostVisibility(post, visibility, password); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, err := seo.apply(&post.SEOMeta); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	joinGroup, err := service.ApplyPostTranslation(post, translation.input())
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+	// 根据状态设置发布时间（published 为当前时间，scheduled 为计划时间）
+	if err := service.ApplyPostStatus(post, status, publishAt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := service.CreatePost(post, categoryIDs, tagIDs, joinGroup); err != nil {
+		if isTranslationError(err) {
+			respondTranslationError(c, err)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "创建失败: " + err.Error()})
 		return
 	}
+	// 新文章已保存，编辑器中的自动保存内容不再需要
+	_ = service.DiscardPostAutosave(currentUserID(c), 0)
 
 	// 确保返回的cover_image是完整URL
 	if post.CoverImage != "" {
 		post.CoverImage = media.GetFullFileURL(post.CoverImage)
 	}
 
+	setVersionETag(c, post.Version)
 	c.JSON(http.StatusOK, gin.H{"post": post})
 }
 
@@ -176,6 +224,21 @@ func handleImageUpload(file *multipart.FileHeader) (string, error) {
 	return filePath, nil
 }
 
+// 解析发布时间，支持 RFC3339 与 "2006-01-02 15:04:05"（按服务器本地时区）
+func parsePublishTime(raw string) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", raw, time.Local); err == nil {
+		return &t, nil
+	}
+	return nil, fmt.Errorf("published_at 格式错误，应为 RFC3339 或 2006-01-02 15:04:05")
+}
+
 // 解析表单中的ID数组
 func parseFormIDArray(values []string) []uint64 {
 	var ids []uint64
@@ -195,6 +258,9 @@ func GetPost(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
 		return
 	}
+	if !checkPostOwner(c, post) {
+		return
+	}
 
 	// 确保返回空数组而不是nil
 	if categories == nil {
@@ -227,6 +293,9 @@ func GetPost(c *gin.Context) {
 		post.CoverImage = media.GetFullFileURL(post.CoverImage)
 	}
 
+	// 其他语言版本（含未发布的）
+	service.AttachPostTranslations(post, false)
+
 	// 返回文章信息及完整的分类和标签信息
 	response := gin.H{
 		"post": post,
@@ -237,12 +306,20 @@ func GetPost(c *gin.Context) {
 		"category_ids": categoryIDs,
 		"tag_ids":      tagIDs,
 	}
+	// 历史slug（旧地址会跳转到当前slug）
+	if history, err := service.ListPostSlugHistory(id); err == nil {
+		response["slug_history"] = history
+	}
+	setVersionETag(c, post.Version)
 	c.JSON(http.StatusOK, response)
 }
 
 // 删除文章（管理后台）
 func DeletePost(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	if _, ok := loadOwnedPost(c, id); !ok {
+		return
+	}
 	if err := service.DeletePost(id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "删除失败: " + err.Error()})
 		return
@@ -256,15 +333,18 @@ func DeletePost(c *gin.Context) {
 // 2. JSON（无文件时，字段名：category_ids, tag_ids）
 func UpdatePost(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
-	post, err := service.GetPostByID(id)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+	post, ok := loadOwnedPost(c, id)
+	if !ok || !checkIfMatch(c, post.Version, "post", post) {
 		return
 	}
+	// 保留更新前的快照，用于写入修订历史
+	before := *post
 
-	var title, content, excerpt, status string
+	var title, slug, content, excerpt, status, publishedAtRaw, visibility, password string
 	var categoryIDs, tagIDs []uint64
 	var coverImageURL string
+	var seo seoFields
+	var translation translationFields
 
 	contentType := c.GetHeader("Content-Type")
 	isFormData := contentType != "" && (strings.Contains(contentType, "multipart/form-data") || strings.Contains(contentType, "application/x-www-form-urlencoded"))
@@ -272,9 +352,24 @@ func UpdatePost(c *gin.Context) {
 	if isFormData {
 		// FormData格式（有文件时）
 		title = c.PostForm("title")
+		slug = c.PostForm("slug")
 		content = c.PostForm("content")
 		excerpt = c.PostForm("excerpt")
 		status = c.PostForm("status")
+		publishedAtRaw = c.PostForm("published_at")
+		visibility = c.PostForm("visibility")
+		password = c.PostForm("password")
+
+		formSEO, err := seoFieldsFromForm(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		seo = formSEO
+		if translation, err = translationFieldsFromForm(c); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		coverImageURL = c.PostForm("cover_image")
 
 		// 处理图片文件
@@ -301,21 +396,33 @@ func UpdatePost(c *gin.Context) {
 		// JSON格式（无文件时）
 		var req struct {
 			Title       string   `json:"title"`
+			Slug        string   `json:"slug"` // 显式指定slug，留空则仅在标题变化时自动生成
 			Content     string   `json:"content"`
 			Excerpt     string   `json:"excerpt"`
 			CoverImage  string   `json:"cover_image"`
 			Status      string   `json:"status"`
+			PublishedAt string   `json:"published_at"` // 定时发布时间，status=scheduled 时必填
+			Visibility  string   `json:"visibility"`   // public/private/password
+			Password    string   `json:"password"`     // visibility=password 时的访问密码，留空则保留原密码
 			CategoryIDs []uint64 `json:"category_ids"` // 前端使用category_ids
 			TagIDs      []uint64 `json:"tag_ids"`      // 前端使用tag_ids
+			seoFields
+			translationFields
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "参数格式错误: " + err.Error()})
 			return
 		}
 		title = req.Title
+		slug = req.Slug
 		content = req.Content
 		excerpt = req.Excerpt
 		status = req.Status
+		publishedAtRaw = req.PublishedAt
+		visibility = req.Visibility
+		password = req.Password
+		seo = req.seoFields
+		translation = req.translationFields
 		// 处理cover_image：转换为完整URL
 		if req.CoverImage != "" {
 			coverImageURL = media.GetFullFileURL(req.CoverImage)
@@ -326,13 +433,20 @@ func UpdatePost(c *gin.Context) {
 
 	hasUpdates := false
 
-	// 更新标题
+	// 更新标题；仅在尚无slug，或标题确有变化且未显式提交slug时重新生成，避免每次保存都改变文章地址
+	slug = strings.TrimSpace(slug)
 	if title != "" {
+		if post.Slug == "" || (title != post.Title && slug == "") {
+			post.Slug = service.EnsureUniqueSlug(service.GenerateSlug(title), id)
+		}
 		post.Title = title
 		hasUpdates = true
-		// 如果标题改变，自动更新slug
-		newSlug := service.GenerateSlug(title)
-		post.Slug = service.EnsureUniqueSlug(newSlug, id)
+	}
+
+	// 显式提交的slug
+	if slug != "" {
+		post.Slug = service.EnsureUniqueSlug(service.GenerateSlug(slug), id)
+		hasUpdates = true
 	}
 
 	// 更新内容
@@ -353,25 +467,69 @@ func UpdatePost(c *gin.Context) {
 		hasUpdates = true
 	}
 
-	// 更新状态
-	if status != "" {
-		oldStatus := post.Status
-		post.Status = status
+	// 更新状态与发布时间（定时发布时 published_at 为计划时间）
+	publishAt, err := parsePublishTime(publishedAtRaw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if status != "" || publishAt != nil {
+		if err = service.ApplyPostStatus(post, status, publishAt); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		hasUpdates = true
-		// 如果状态从非published变为published，设置发布时间
-		if oldStatus != "published" && status == "published" && post.PublishedAt == nil {
-			now := time.Now()
-			post.PublishedAt = &now
+	}
+
+	// 更新可见性与访问密码
+	if visibility != "" || password != "" {
+		if err = service.ApplyPostVisibility(post, visibility, password); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-		// 如果从published改为其他状态，清空发布时间
-		if oldStatus == "published" && status != "published" {
-			post.PublishedAt = nil
+		hasUpdates = true
+	}
+
+	// 更新 SEO 元数据（只覆盖提交了的字段）
+	if changed, err := seo.apply(&post.SEOMeta); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	} else if changed {
+		hasUpdates = true
+	}
+
+	// 更新语言与翻译组，原文的翻译组随本次保存写入
+	var writes []service.ContentWrite
+	if translation.submitted() {
+		joinGroup, err := service.ApplyPostTranslation(post, translation.input())
+		if err != nil {
+			respondTranslationError(c, err)
+			return
 		}
+		writes = append(writes, joinGroup)
+		hasUpdates = true
+	}
+
+	// 只修改分类标签时同样保存文章，使版本号递增
+	if categoryIDs != nil || tagIDs != nil {
+		hasUpdates = true
 	}
 
 	// 更新文章基本信息
 	if hasUpdates {
-		if err = service.UpdatePost(post); err != nil {
+		// 文本内容有变化时，旧内容随本次保存写入一条修订
+		if service.PostRevisionChanged(&before, post) {
+			writes = append(writes, service.PostRevisionWrite(&before, currentUserID(c), service.RevisionReasonUpdate))
+		}
+		if err = service.UpdatePost(post, writes...); err != nil {
+			if errors.Is(err, service.ErrVersionConflict) {
+				respondLatestPost(c, id)
+				return
+			}
+			if isTranslationError(err) {
+				respondTranslationError(c, err)
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "更新失败: " + err.Error()})
 			return
 		}
@@ -391,6 +549,8 @@ func UpdatePost(c *gin.Context) {
 		}
 	}
 
+	_ = service.DiscardPostAutosave(currentUserID(c), id)
+
 	// 重新获取文章（包含最新的关联数据）
 	updatedPost, categories, tags, _ := service.GetPostWithFullRelations(id)
 
@@ -398,6 +558,7 @@ func UpdatePost(c *gin.Context) {
 	if updatedPost.CoverImage != "" {
 		updatedPost.CoverImage = media.GetFullFileURL(updatedPost.CoverImage)
 	}
+	service.AttachPostTranslations(updatedPost, false)
 
 	// 构建响应
 	response := gin.H{
@@ -405,6 +566,7 @@ func UpdatePost(c *gin.Context) {
 		"categories": categories,
 		"tags":       tags,
 	}
+	setVersionETag(c, updatedPost.Version)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -413,17 +575,31 @@ func ListPosts(c *gin.Context) {
 	var req struct {
 		Page     int    `form:"page"`
 		PageSize int    `form:"page_size"`
-		Size     int    `form:"size"` // 兼容旧参数名
+		Size     int    `form:"size"`   // 兼容旧参数名
+		Cursor   string `form:"cursor"` // 游标分页，第一页传空值
 		Q        string `form:"q"`
 		Sort     string `form:"sort"`
 		Category string `form:"category"`
 		Tag      string `form:"tag"`
+		Author   string `form:"author"` // 作者用户名
 		Status   string `form:"status"` // 管理员可以筛选所有状态
+		Lang     string `form:"lang"`
 	}
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	lang, err := service.ParseLangFilter(req.Lang)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// 作者只能看到自己的文章
+	if c.GetString("user_role") == "author" {
+		if user, ok := c.Get("user"); ok {
+			req.Author = user.(*models.User).Username
+		}
+	}
 
 	// 兼容旧参数名
 	pageSize := req.PageSize
@@ -431,8 +607,24 @@ func ListPosts(c *gin.Context) {
 		pageSize = req.Size
 	}
 
+	// 带 cursor 参数时使用游标分页，否则保持页码分页
+	if _, ok := c.GetQuery("cursor"); ok {
+		result, err := service.ListPostsByCursor(req.Cursor, pageSize, req.Q, req.Sort, req.Category, req.Tag, req.Author, req.Status, service.PostScopeAll, lang)
+		if err != nil {
+			respondCursorError(c, err)
+			return
+		}
+		for i := range result.Posts {
+			if result.Posts[i].CoverImage != "" {
+				result.Posts[i].CoverImage = media.GetFullFileURL(result.Posts[i].CoverImage)
+			}
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
 	// 使用分页服务
-	result, err := service.ListPostsWithPagination(req.Page, pageSize, req.Q, req.Sort, req.Category, req.Tag, req.Status)
+	result, err := service.ListPostsWithPagination(req.Page, pageSize, req.Q, req.Sort, req.Category, req.Tag, req.Author, req.Status, service.PostScopeAll, lang)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
 		return
@@ -447,3 +639,43 @@ func ListPosts(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// respondCursorError 游标无法解析时返回 400，其余为查询失败
+func respondCursorError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+}
+
+// respondLatestPost 保存时版本已变化（读取后被其他请求修改），返回 409 与最新的文章
+func respondLatestPost(c *gin.Context, id uint64) {
+	post, err := service.GetPostByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+		return
+	}
+	respondVersionConflict(c, post.Version, "post", post)
+}
+
+// loadOwnedPost 读取当前用户可编辑的文章，不存在或无权限时写入响应并返回 false
+func loadOwnedPost(c *gin.Context, id uint64) (*models.Post, bool) {
+	post, err := service.GetPostByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+		return nil, false
+	}
+	return post, checkPostOwner(c, post)
+}
+
+// checkPostOwner 作者角色只能操作自己的文章，管理员不受限制
+func checkPostOwner(c *gin.Context, post *models.Post) bool {
+	userID, _ := c.Get("user_id")
+	editorID, _ := userID.(uint64)
+	if service.CanEditPost(post, editorID, c.GetString("user_role")) {
+		return true
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "只能编辑自己的文章"})
+	return false
+}
@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"api/internal/modules/media"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 文章修订列表（管理后台）
+func ListPostRevisions(c *gin.Context) {
+	postID, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	if _, ok := loadOwnedPost(c, postID); !ok {
+		return
+	}
+
+	revisions, err := service.ListPostRevisions(postID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询修订失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions, "total": len(revisions)})
+}
+
+// 获取单个修订详情（含正文）
+func GetPostRevision(c *gin.Context) {
+	postID, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	revisionID, _ := strconv.ParseUint(c.Param("revisionId"), 10, 64)
+	if _, ok := loadOwnedPost(c, postID); !ok {
+		return
+	}
+
+	revision, err := service.GetPostRevision(postID, revisionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "修订不存在"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revision": revision})
+}
+
+// 对比两个修订的行级差异
+// GET /api/admin/posts/:id/revisions/diff?from=<revision_id>&to=<revision_id>
+// to 省略或为 0 时与文章当前内容对比
+func DiffPostRevisions(c *gin.Context) {
+	postID, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	if _, ok := loadOwnedPost(c, postID); !ok {
+		return
+	}
+	fromID, err := strconv.ParseUint(c.Query("from"), 10, 64)
+	if err != nil || fromID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少有效的 from 参数"})
+		return
+	}
+	toID, _ := strconv.ParseUint(c.DefaultQuery("to", "0"), 10, 64)
+
+	diff, err := service.DiffPostRevisions(postID, fromID, toID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "修订不存在"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "对比失败: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"diff": diff})
+}
+
+// 恢复文章到指定修订
+func RestorePostRevision(c *gin.Context) {
+	postID, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	revisionID, _ := strconv.ParseUint(c.Param("revisionId"), 10, 64)
+	current, ok := loadOwnedPost(c, postID)
+	if !ok || !checkIfMatch(c, current.Version, "post", current) {
+		return
+	}
+	userID, _ := c.Get("user_id")
+	editorID, _ := userID.(uint64)
+
+	post, err := service.RestorePostRevision(postID, revisionID, editorID, current.Version)
+	if err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			respondLatestPost(c, postID)
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "文章或修订不存在"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "恢复失败: " + err.Error()})
+		}
+		return
+	}
+
+	if post.CoverImage != "" {
+		post.CoverImage = media.GetFullFileURL(post.CoverImage)
+	}
+	setVersionETag(c, post.Version)
+	c.JSON(http.StatusOK, gin.H{"post": post, "message": "恢复成功"})
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 为未发布的文章/页面/动态生成签名预览链接
+// POST /api/admin/previews/:type/:id  {"ttl": "24h"}，ttl 可省略，默认 PREVIEW_TTL
+func CreatePreviewLink(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	var req struct {
+		TTL string `json:"ttl"`
+	}
+	// 请求体可为空
+	_ = c.ShouldBindJSON(&req)
+
+	var ttl time.Duration
+	if raw := strings.TrimSpace(req.TTL); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ttl 格式错误，例如 24h、30m"})
+			return
+		}
+		ttl = parsed
+	}
+
+	link, err := service.CreatePreviewLink(c.Param("type"), id, ttl)
+	if err != nil {
+		respondPreviewError(c, err, "生成预览链接失败: ")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"preview": link})
+}
+
+// 撤销内容已发出的全部预览链接
+// DELETE /api/admin/previews/:type/:id
+func RevokePreviewLinks(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err := service.RevokePreviewLinks(c.Param("type"), id); err != nil {
+		respondPreviewError(c, err, "撤销预览链接失败: ")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "已撤销全部预览链接"})
+}
+
+func respondPreviewError(c *gin.Context, err error, prefix string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "内容不存在"})
+	case errors.Is(err, service.ErrPreviewUnsupported), errors.Is(err, service.ErrPreviewPublished):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": prefix + err.Error()})
+	}
+}
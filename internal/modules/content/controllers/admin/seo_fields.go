@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/modules/content/service"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// seoFields 文章与页面共用的 SEO 请求字段；为 nil 表示未提交，更新时保留原值
+type seoFields struct {
+	MetaTitle       *string `json:"meta_title"`
+	MetaDescription *string `json:"meta_description"`
+	CanonicalURL    *string `json:"canonical_url"`
+	OGImage         *string `json:"og_image"`
+	NoIndex         *bool   `json:"noindex"`
+}
+
+// 从 FormData 读取 SEO 字段
+func seoFieldsFromForm(c *gin.Context) (seoFields, error) {
+	var f seoFields
+	if v, ok := c.GetPostForm("meta_title"); ok {
+		f.MetaTitle = &v
+	}
+	if v, ok := c.GetPostForm("meta_description"); ok {
+		f.MetaDescription = &v
+	}
+	if v, ok := c.GetPostForm("canonical_url"); ok {
+		f.CanonicalURL = &v
+	}
+	if v, ok := c.GetPostForm("og_image"); ok {
+		f.OGImage = &v
+	}
+	if v, ok := c.GetPostForm("noindex"); ok && v != "" {
+		noindex, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, fmt.Errorf("noindex 只能是 true 或 false")
+		}
+		f.NoIndex = &noindex
+	}
+	return f, nil
+}
+
+// apply 写入已提交的字段并校验，返回是否有字段被提交
+func (f seoFields) apply(meta *models.SEOMeta) (bool, error) {
+	changed := false
+	if f.MetaTitle != nil {
+		meta.MetaTitle, changed = *f.MetaTitle, true
+	}
+	if f.MetaDescription != nil {
+		meta.MetaDescription, changed = *f.MetaDescription, true
+	}
+	if f.CanonicalURL != nil {
+		meta.CanonicalURL, changed = *f.CanonicalURL, true
+	}
+	if f.OGImage != nil {
+		meta.OGImage, changed = *f.OGImage, true
+	}
+	if f.NoIndex != nil {
+		meta.NoIndex, changed = *f.NoIndex, true
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, service.NormalizeSEOMeta(meta)
+}
@@ -0,0 +1,166 @@
+package admin
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/modules/content/service"
+	"api/internal/modules/media"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 系列列表（管理后台）
+func ListSeries(c *gin.Context) {
+	list, err := service.ListSeries()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"series": list})
+}
+
+// 系列详情（包含全部状态的文章）
+func GetSeries(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	series, err := service.GetSeriesByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "系列不存在"})
+		return
+	}
+	parts, err := service.ListSeriesParts(id, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+		return
+	}
+	series.PostCount = len(parts)
+	c.JSON(http.StatusOK, gin.H{"series": series, "posts": parts})
+}
+
+// 创建系列
+func CreateSeries(c *gin.Context) {
+	var req struct {
+		Title       string   `json:"title" binding:"required"`
+		Slug        string   `json:"slug"` // 可选，不传则自动生成
+		Description string   `json:"description"`
+		CoverImage  string   `json:"cover_image"`
+		PostIDs     []uint64 `json:"post_ids"` // 可选，按顺序排列的文章ID
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series := &models.Series{
+		Title:       req.Title,
+		Slug:        req.Slug,
+		Description: req.Description,
+		CoverImage:  req.CoverImage,
+	}
+	if series.CoverImage != "" {
+		series.CoverImage = media.GetFullFileURL(series.CoverImage)
+	}
+	if err := service.CreateSeries(series); err != nil {
+		respondSeriesError(c, err, "创建失败: ")
+		return
+	}
+	if req.PostIDs != nil {
+		if err := service.SetSeriesPosts(series.ID, req.PostIDs); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "设置系列文章失败: " + err.Error()})
+			return
+		}
+		series.PostCount = len(req.PostIDs)
+	}
+	c.JSON(http.StatusOK, gin.H{"series": series})
+}
+
+// 更新系列基本信息
+func UpdateSeries(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	series, err := service.GetSeriesByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "系列不存在"})
+		return
+	}
+
+	var req struct {
+		Title       string  `json:"title"`
+		Slug        string  `json:"slug"`
+		Description *string `json:"description"`
+		CoverImage  *string `json:"cover_image"`
+	}
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Title != "" {
+		series.Title = req.Title
+	}
+	if req.Slug != "" {
+		series.Slug = req.Slug
+	}
+	if req.Description != nil {
+		series.Description = *req.Description
+	}
+	if req.CoverImage != nil {
+		series.CoverImage = *req.CoverImage
+		if series.CoverImage != "" {
+			series.CoverImage = media.GetFullFileURL(series.CoverImage)
+		}
+	}
+
+	if err = service.UpdateSeries(series); err != nil {
+		respondSeriesError(c, err, "更新失败: ")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"series": series})
+}
+
+// 按顺序重设系列文章
+// PUT /api/admin/series/:id/posts  {"post_ids": [3, 1, 2]}
+func SetSeriesPosts(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	if _, err := service.GetSeriesByID(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "系列不存在"})
+		return
+	}
+
+	var req struct {
+		PostIDs []uint64 `json:"post_ids"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := service.SetSeriesPosts(id, req.PostIDs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "设置系列文章失败: " + err.Error()})
+		return
+	}
+
+	parts, err := service.ListSeriesParts(id, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"posts": parts})
+}
+
+// 删除系列（文章本身保留）
+func DeleteSeries(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err := service.DeleteSeries(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "删除失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "删除成功"})
+}
+
+func respondSeriesError(c *gin.Context, err error, prefix string) {
+	if errors.Is(err, service.ErrSeriesSlugExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": prefix + err.Error()})
+}
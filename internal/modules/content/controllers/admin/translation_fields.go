@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// translationFields 文章与页面共用的语言与翻译组请求字段；为 nil 表示未提交，更新时保留原值
+type translationFields struct {
+	Lang          *string `json:"lang"`
+	TranslationOf *uint64 `json:"translation_of"` // 作为该内容的翻译加入其翻译组，0 表示脱离翻译组
+}
+
+// 从 FormData 读取语言与翻译组字段
+func translationFieldsFromForm(c *gin.Context) (translationFields, error) {
+	var f translationFields
+	if v, ok := c.GetPostForm("lang"); ok {
+		f.Lang = &v
+	}
+	if v, ok := c.GetPostForm("translation_of"); ok && v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return f, fmt.Errorf("translation_of 必须是文章或页面ID")
+		}
+		f.TranslationOf = &id
+	}
+	return f, nil
+}
+
+func (f translationFields) submitted() bool {
+	return f.Lang != nil || f.TranslationOf != nil
+}
+
+func (f translationFields) input() service.TranslationInput {
+	return service.TranslationInput{Lang: f.Lang, TranslationOf: f.TranslationOf}
+}
+
+// respondTranslationError 同语言版本已存在时返回 409，其余为参数错误
+// isTranslationError 保存时写入翻译组失败：原文已被删除，或并发请求已在同一组中加入了相同语言
+func isTranslationError(err error) bool {
+	return errors.Is(err, service.ErrTranslationExists) || errors.Is(err, service.ErrTranslationSource)
+}
+
+func respondTranslationError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, service.ErrTranslationExists):
+		status = http.StatusConflict
+	case !errors.Is(err, service.ErrInvalidLang) && !errors.Is(err, service.ErrTranslationSource):
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "设置翻译失败: " + err.Error()})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
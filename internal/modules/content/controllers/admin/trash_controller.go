@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"api/internal/modules/media"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 回收站列表
+// GET /api/admin/trash?type=post|comment&page=&page_size=
+func ListTrash(c *gin.Context) {
+	var req struct {
+		Type     string `form:"type"`
+		Page     int    `form:"page"`
+		PageSize int    `form:"page_size"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch req.Type {
+	case "", service.TrashTypePost:
+		result, err := service.ListPostsWithPagination(req.Page, req.PageSize, "", "DESC", "", "", "", service.StatusTrash, service.PostScopeAll, "")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+			return
+		}
+		for i := range result.Posts {
+			if result.Posts[i].CoverImage != "" {
+				result.Posts[i].CoverImage = media.GetFullFileURL(result.Posts[i].CoverImage)
+			}
+		}
+		c.JSON(http.StatusOK, result)
+	case service.TrashTypeComment:
+		result, err := service.ListCommentsWithPagination(req.Page, req.PageSize, 0, service.StatusTrash, "", "DESC")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidTrashType.Error()})
+	}
+}
+
+// 从回收站恢复（恢复为移入前的状态）
+// POST /api/admin/trash/restore {"type": "post", "ids": [1, 2]}
+func RestoreTrash(c *gin.Context) {
+	var req struct {
+		Type string   `json:"type" binding:"required"`
+		IDs  []uint64 `json:"ids" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	restored, err := service.RestoreTrash(req.Type, req.IDs)
+	if err != nil {
+		respondTrashError(c, "恢复失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "恢复成功", "count": restored})
+}
+
+// 彻底删除回收站中的单条内容
+// DELETE /api/admin/trash/:type/:id
+func PurgeTrashItem(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	purged, err := service.PurgeTrashItem(c.Param("type"), id)
+	if err != nil {
+		respondTrashError(c, "删除失败", err)
+		return
+	}
+	if !purged {
+		c.JSON(http.StatusNotFound, gin.H{"error": "回收站中不存在该内容"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "已彻底删除"})
+}
+
+// 清空回收站，type 为空时同时清空文章和评论
+// DELETE /api/admin/trash?type=post|comment
+func EmptyTrash(c *gin.Context) {
+	report, err := service.PurgeTrash(c.Query("type"), time.Time{})
+	if err != nil {
+		respondTrashError(c, "清空失败", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "回收站已清空", "purged": report})
+}
+
+func respondTrashError(c *gin.Context, action string, err error) {
+	if errors.Is(err, service.ErrInvalidTrashType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": action + ": " + err.Error()})
+}
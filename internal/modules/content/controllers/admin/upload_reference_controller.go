@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"api/internal/modules/content/service"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 列出没有被任何内容引用的上传文件
+// GET /api/admin/upload/orphans?min_age=168h（只列出最后修改时间早于 min_age 的文件，默认全部列出）
+func ListOrphanUploads(c *gin.Context) {
+	var minAge time.Duration
+	if raw := c.Query("min_age"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_age 格式错误，示例：72h"})
+			return
+		}
+		minAge = d
+	}
+
+	files, err := service.ListOrphanUploads(minAge)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+		return
+	}
+	var totalSize int64
+	for _, file := range files {
+		totalSize += file.Size
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"files":      files,
+		"total":      len(files),
+		"total_size": totalSize,
+	})
+}
+
+// 查询上传文件被哪些内容引用
+// GET /api/admin/upload/references?path=/uploads/images/xxx.png
+func GetUploadReferences(c *gin.Context) {
+	target := c.Query("path")
+	if target == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少文件路径参数"})
+		return
+	}
+
+	references, err := service.GetUploadReferences(target)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidUploadPath) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"path":       target,
+		"references": references,
+		"total":      len(references),
+	})
+}
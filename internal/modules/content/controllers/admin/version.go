@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 编辑冲突检测：详情与写入接口通过 ETag 返回当前版本号，
+// 更新接口必须携带 If-Match（取自最近一次 ETag），版本不一致时返回 409 与服务器上的最新内容
+
+// setVersionETag 以版本号作为 ETag，如 "3"
+func setVersionETag(c *gin.Context, version uint64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatUint(version, 10)))
+}
+
+// checkIfMatch 校验 If-Match：缺失时返回 428，与当前版本不一致时返回 409，均写入响应并返回 false。
+// key 与 current 为冲突响应中携带的最新内容（如 "post" 与文章对象）
+func checkIfMatch(c *gin.Context, version uint64, key string, current interface{}) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "缺少 If-Match 请求头，请先获取最新内容"})
+		return false
+	}
+	if ifMatchVersion(header, version) {
+		return true
+	}
+	respondVersionConflict(c, version, key, current)
+	return false
+}
+
+// respondVersionConflict 返回 409 与服务器上的最新内容，前端据此合并或提示用户
+func respondVersionConflict(c *gin.Context, version uint64, key string, current interface{}) {
+	setVersionETag(c, version)
+	c.JSON(http.StatusConflict, gin.H{
+		"error":   "内容已被其他人修改，请合并后重试",
+		"version": version,
+		key:       current,
+	})
+}
+
+// ifMatchVersion If-Match 可以是逗号分隔的多个 ETag，也可以是 *；弱校验前缀 W/ 按同一版本处理
+func ifMatchVersion(header string, version uint64) bool {
+	want := strconv.FormatUint(version, 10)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.Trim(strings.TrimPrefix(tag, "W/"), `"`) == want {
+			return true
+		}
+	}
+	return false
+}
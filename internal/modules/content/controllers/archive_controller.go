@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"api/internal/modules/content/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 归档：按年月统计已发布文章数
+// GET /api/archives
+func ListArchives(c *gin.Context) {
+	months, err := service.ListArchiveMonths()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
+		return
+	}
+	var total int64
+	for _, m := range months {
+		total += m.Count
+	}
+	c.JSON(http.StatusOK, gin.H{"archives": months, "total": total})
+}
+
+// 某月归档文章列表（仅标题、slug 与发布时间）
+// GET /api/archives/:year/:month
+func GetArchiveMonth(c *gin.Context) {
+	year, errYear := strconv.Atoi(c.Param("year"))
+	month, errMonth := strconv.Atoi(c.Param("month"))
+	if errYear != nil || errMonth != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidArchiveMonth.Error()})
+		return
+	}
+	posts, err := service.ListArchivePosts(year, month)
+	if err != nil {
+		if err == service.ErrInvalidArchiveMonth {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"year": year, "month": month, "posts": posts})
+}
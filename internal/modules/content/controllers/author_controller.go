@@ -0,0 +1,25 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"api/internal/modules/content/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 作者主页
+// GET /api/authors/:username，作者的文章列表使用 /api/posts?author=<username>
+func GetAuthorProfile(c *gin.Context) {
+	profile, err := service.GetAuthorProfile(c.Param("username"))
+	if err != nil {
+		if errors.Is(err, service.ErrAuthorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "作者不存在"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"author": profile})
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"api/internal/modules/content/service"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 全站订阅源
+// GET /api/feeds/:format（format: rss/atom/json），所有订阅源都支持 ?lang=en 只输出该语言的文章
+func GetSiteFeed(c *gin.Context) {
+	serveFeed(c, service.FeedScopeAll, "")
+}
+
+// 分类订阅源
+// GET /api/feeds/category/:slug/:format
+func GetCategoryFeed(c *gin.Context) {
+	serveFeed(c, service.FeedScopeCategory, c.Param("slug"))
+}
+
+// 标签订阅源
+// GET /api/feeds/tag/:slug/:format
+func GetTagFeed(c *gin.Context) {
+	serveFeed(c, service.FeedScopeTag, c.Param("slug"))
+}
+
+func serveFeed(c *gin.Context, scope, slug string) {
+	lang, err := service.ParseLangFilter(c.Query("lang"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	feed, err := service.GetFeed(scope, slug, c.Param("format"), lang)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrFeedFormat):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrFeedLang):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "分类或标签不存在"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "生成订阅源失败"})
+		}
+		return
+	}
+
+	c.Header("ETag", feed.ETag)
+	c.Header("Last-Modified", feed.LastModified.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "public, max-age=300")
+
+	if feedNotModified(c, feed) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, feed.ContentType, feed.Body)
+}
+
+// feedNotModified 按 RFC 7232：存在 If-None-Match 时忽略 If-Modified-Since
+func feedNotModified(c *gin.Context, feed *service.Feed) bool {
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == feed.ETag {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := time.Parse(http.TimeFormat, c.GetHeader("If-Modified-Since"))
+	return err == nil && !feed.LastModified.After(since)
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"api/internal/modules/content/models"
 	"api/internal/modules/content/service"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -42,21 +43,22 @@ func GetPage(c *gin.Context) {
 	var err error
 
 	// 尝试作为ID解析
-	if id, err := strconv.ParseUint(param, 10, 64); err == nil {
+	if id, parseErr := strconv.ParseUint(param, 10, 64); parseErr == nil {
 		page, err = service.GetPageByID(id)
 	} else {
 		// 作为slug处理
 		page, err = service.GetPageBySlug(param)
 	}
 
-	if err != nil {
+	if err != nil || !service.CheckPageAccess(page, c.GetString("user_role"), previewSigned(c, service.PreviewTypePage, page.ID)) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "页面不存在"})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"page": page})
+	service.AttachPageTranslations(page, true)
+	c.JSON(http.StatusOK, gin.H{"page": page, "seo": service.BuildPageSEO(page)})
 }
 func ListPages(c *gin.Context) {
-	pages, err := service.ListPages()
+	pages, err := service.ListPublishedPages()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
 		return
@@ -90,6 +92,10 @@ func UpdatePage(c *gin.Context) {
 		page.Excerpt = req.Excerpt
 	}
 	if err = service.UpdatePage(page); err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "更新失败"})
 		return
 	}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"api/internal/modules/content/models"
 	"api/internal/modules/content/service"
@@ -12,6 +15,7 @@ import (
 )
 
 // 创建文章
+// 作者固定为当前登录用户，只有管理员可以通过 author_id 代其他用户创建
 func CreatePost(c *gin.Context) {
 	var req struct {
 		Title       string   `json:"title" binding:"required"`
@@ -19,17 +23,21 @@ func CreatePost(c *gin.Context) {
 		Content     string   `json:"content" binding:"required"`
 		CategoryIDs []uint64 `json:"categories"`
 		TagIDs      []uint64 `json:"tags"`
-		AuthorID    uint64   `json:"author_id" binding:"required"`
+		AuthorID    uint64   `json:"author_id"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	authorID := viewerID(c)
+	if req.AuthorID != 0 && c.GetString("user_role") == "admin" {
+		authorID = req.AuthorID
+	}
 	post := models.Post{
 		Title:    req.Title,
 		Slug:     req.Slug,
 		Content:  req.Content,
-		AuthorID: req.AuthorID,
+		AuthorID: authorID,
 	}
 	if err := service.CreatePost(&post, req.CategoryIDs, req.TagIDs); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "创建失败"})
@@ -41,7 +49,41 @@ func CreatePost(c *gin.Context) {
 // 获取文章详情（包含完整的分类和标签信息）
 func GetPost(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	respondPostDetail(c, id)
+}
+
+// 通过slug获取文章详情
+// GET /api/posts/slug/:slug，命中历史slug时返回 301，Location 与响应体中的 slug 为当前地址
+func GetPostBySlug(c *gin.Context) {
+	post, redirected, err := service.ResolvePostSlug(c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+		return
+	}
+	if redirected {
+		// 私密、未发布文章的旧地址同样视为不存在，避免泄露当前slug
+		if checkPostAccess(c, post, "") == service.PostAccessNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+			return
+		}
+		location := "/api/posts/slug/" + url.PathEscape(post.Slug)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery // 保留预览签名、访问令牌等参数
+		}
+		c.Header("Location", location)
+		c.JSON(http.StatusMovedPermanently, gin.H{
+			"error":    "文章地址已变更",
+			"redirect": true,
+			"id":       post.ID,
+			"slug":     post.Slug,
+		})
+		return
+	}
+	respondPostDetail(c, post.ID)
+}
 
+// 输出文章详情（按ID与按slug查询共用）
+func respondPostDetail(c *gin.Context, id uint64) {
 	// 使用GetPostWithFullRelations获取文章及其关联的分类和标签
 	post, categories, tags, err := service.GetPostWithFullRelations(id)
 	if err != nil {
@@ -49,8 +91,35 @@ func GetPost(c *gin.Context) {
 		return
 	}
 
-	// 记录浏览流量（每次访问详情都增加一次浏览数）
-	service.RecordView(id)
+	// 可见性校验：未发布与私密文章对访客不可见，密码文章需携带解锁令牌
+	switch checkPostAccess(c, post, postAccessToken(c)) {
+	case service.PostAccessNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+		return
+	case service.PostAccessLocked:
+		if post.CoverImage != "" {
+			post.CoverImage = media.GetFullFileURL(post.CoverImage)
+		}
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":  "该文章需要密码访问",
+			"locked": true,
+			"post": gin.H{
+				"id":           post.ID,
+				"title":        post.Title,
+				"slug":         post.Slug,
+				"cover_image":  post.CoverImage,
+				"visibility":   post.Visibility,
+				"published_at": post.PublishedAt,
+				"seo":          service.BuildLockedPostSEO(post),
+			},
+		})
+		return
+	}
+
+	// 记录浏览流量（每次访问详情都增加一次浏览数，作者、预览查看未发布文章时不计）
+	if post.Status == "published" {
+		service.RecordView(id)
+	}
 
 	// 确保返回空数组而不是nil
 	if categories == nil {
@@ -83,6 +152,11 @@ func GetPost(c *gin.Context) {
 		post.CoverImage = media.GetFullFileURL(post.CoverImage)
 	}
 
+	// 服务端渲染正文（按文章ID + UpdatedAt 缓存）
+	rendered := service.RenderPostContent(post)
+	// 其他语言版本，只列出访客可见的
+	service.AttachPostTranslations(post, true)
+
 	// 构建响应数据，符合前端期望的数据结构
 	// 将categories和tags直接添加到post对象中，方便前端直接使用
 	postWithRelations := gin.H{
@@ -90,9 +164,14 @@ func GetPost(c *gin.Context) {
 		"title":         post.Title,
 		"slug":          post.Slug,
 		"content":       post.Content,
+		"content_html":  rendered.HTML,           // 已过滤的 HTML，标题带锚点
+		"toc":           rendered.TOC,            // 目录树 [{level, text, anchor, children}]
+		"word_count":    rendered.WordCount,      // 字数（汉字按字、英文按词）
+		"reading_time":  rendered.ReadingMinutes, // 预计阅读分钟数
 		"excerpt":       post.Excerpt,
 		"cover_image":   post.CoverImage,
 		"author_id":     post.AuthorID,
+		"author":        post.Author, // 作者 {id, username, display_name, avatar_url}
 		"status":        post.Status,
 		"visibility":    post.Visibility,
 		"view_count":    post.ViewCount,
@@ -105,6 +184,10 @@ func GetPost(c *gin.Context) {
 		"tags":          tags,       // 直接包含完整的标签对象数组 [{id, name, slug}, ...]
 		"category_ids":  categoryIDs,
 		"tag_ids":       tagIDs,
+		"series":        post.Series, // 系列导航 {id, title, slug, position, total, prev, next}，不属于系列时为 null
+		"lang":          post.Lang,
+		"translations":  post.Translations, // 其他语言版本 [{id, lang, slug, title}]，没有时为 null
+		"seo":           service.BuildPostSEO(post, tags),
 	}
 
 	// 返回响应，post对象中包含完整的categories和tags
@@ -117,32 +200,186 @@ func GetPost(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// 校验文章访问密码，返回短期有效的访问令牌
+// POST /api/posts/:id/unlock  {"password": "..."}
+// 后续请求文章详情时通过 X-Post-Token 头或 access_token 参数携带令牌
+func UnlockPost(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	var req struct {
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请输入访问密码"})
+		return
+	}
+
+	post, err := service.GetPostByID(id)
+	if err != nil || post.Visibility == service.VisibilityPrivate {
+		c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+		return
+	}
+	if post.Visibility != service.VisibilityPassword {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "该文章无需密码"})
+		return
+	}
+
+	token, expiresAt, err := service.UnlockPost(post, req.Password)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token, "expires_at": expiresAt})
+}
+
+// 文章详情的访问校验，按ID、按slug查询与历史slug跳转共用
+func checkPostAccess(c *gin.Context, post *models.Post, token string) service.PostAccess {
+	return service.CheckPostAccess(post, viewerID(c), c.GetString("user_role"), token, previewSigned(c, service.PreviewTypePost, post.ID))
+}
+
+// 请求是否携带有效的预览签名（expires、sig 参数，与预览链接相同），用于查看未发布内容
+func previewSigned(c *gin.Context, contentType string, id uint64) bool {
+	sig := c.Query("sig")
+	if sig == "" {
+		return false
+	}
+	expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+	return service.VerifyPreviewLink(contentType, id, expires, sig) == nil
+}
+
+// 读取文章访问令牌（请求头优先）
+func postAccessToken(c *gin.Context) string {
+	if token := strings.TrimSpace(c.GetHeader("X-Post-Token")); token != "" {
+		return token
+	}
+	return strings.TrimSpace(c.Query("access_token"))
+}
+
+// 当前登录用户ID，未登录为 0
+func viewerID(c *gin.Context) uint64 {
+	userID, _ := c.Get("user_id")
+	id, _ := userID.(uint64)
+	return id
+}
+
+// 相关文章推荐（按共同标签、分类、文本相似度与新近度综合排序）
+// GET /api/posts/:id/related?limit=5
+func GetRelatedPosts(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	post, err := service.GetPostByID(id)
+	if err != nil || post.Status != "published" || post.Visibility == service.VisibilityPrivate {
+		c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	related, err := service.GetRelatedPosts(id, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
+		return
+	}
+	for i := range related {
+		if related[i].CoverImage != "" {
+			related[i].CoverImage = media.GetFullFileURL(related[i].CoverImage)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"posts": related})
+}
+
 // 文章列表
+// 默认按 page/size 分页；带 cursor 参数（第一页传空值）时改用游标分页，返回 next_cursor/prev_cursor
 func ListPosts(c *gin.Context) {
 	//接收参数
 	var req struct {
-		Page     int    `form:"page" binding:"required"`
-		Size     int    `form:"size" binding:"required"`
+		Page     int    `form:"page"`
+		Size     int    `form:"size"`
+		Cursor   string `form:"cursor"`
 		Q        string `form:"q"`
 		Sort     string `form:"sort"`
 		Category string `form:"category"`
 		Tag      string `form:"tag"`
+		Author   string `form:"author"` // 作者用户名
+		Lang     string `form:"lang"`   // 语言代码，如 en、zh-CN
 	}
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	lang, err := service.ParseLangFilter(req.Lang)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	_, cursorMode := c.GetQuery("cursor")
+	if !cursorMode && (req.Page == 0 || req.Size == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "page 和 size 为必填参数"})
+		return
+	}
+
+	// 有关键词且未指定排序时按相关度检索，返回高亮片段与命中次数（不支持游标分页）
+	if !cursorMode && strings.TrimSpace(req.Q) != "" && req.Sort == "" {
+		searchPosts(c, req.Page, req.Size, req.Q, req.Category, req.Tag, req.Author, lang)
+		return
+	}
+
 	sort := req.Sort
 	if sort == "" {
 		sort = "DESC"
 	}
-	resp, err := service.ListPostsWithPagination(req.Page, req.Size, req.Q, sort, req.Category, req.Tag, "published")
+	// 私密文章不出现在公开列表；带关键词时只匹配公开文章，避免通过检索探测密码文章正文
+	scope := service.PostScopeListed
+	if strings.TrimSpace(req.Q) != "" {
+		scope = service.PostScopePublic
+	}
+
+	if cursorMode {
+		resp, err := service.ListPostsByCursor(req.Cursor, req.Size, req.Q, sort, req.Category, req.Tag, req.Author, "published", scope, lang)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidCursor) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
+			}
+			return
+		}
+		prepareListedPosts(resp.Posts)
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	resp, err := service.ListPostsWithPagination(req.Page, req.Size, req.Q, sort, req.Category, req.Tag, req.Author, "published", scope, lang)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
+		return
+	}
+	prepareListedPosts(resp.Posts)
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts":       resp.Posts,
+		"total":       resp.Total,
+		"page":        resp.Page,
+		"page_size":   resp.PageSize,
+		"total_pages": resp.TotalPages,
+	})
+}
+
+// 公开列表：隐藏密码文章正文，并确保cover_image是完整URL
+func prepareListedPosts(posts []models.PostWithRelations) {
+	service.MaskLockedPosts(posts)
+	for i := range posts {
+		if posts[i].CoverImage != "" {
+			posts[i].CoverImage = media.GetFullFileURL(posts[i].CoverImage)
+		}
+	}
+}
+
+// 按相关度检索已发布文章
+func searchPosts(c *gin.Context, page, size int, q, category, tag, author, lang string) {
+	resp, err := service.SearchPosts(page, size, q, category, tag, author, "published", service.PostScopePublic, lang)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
 		return
 	}
 
-	// 确保列表中的cover_image都是完整URL
 	for i := range resp.Posts {
 		if resp.Posts[i].CoverImage != "" {
 			resp.Posts[i].CoverImage = media.GetFullFileURL(resp.Posts[i].CoverImage)
@@ -155,6 +392,8 @@ func ListPosts(c *gin.Context) {
 		"page":        resp.Page,
 		"page_size":   resp.PageSize,
 		"total_pages": resp.TotalPages,
+		"q":           resp.Query,
+		"order":       "relevance",
 	})
 }
 
@@ -166,6 +405,10 @@ func UpdatePost(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "不存在"})
 		return
 	}
+	if !service.CanEditPost(post, viewerID(c), c.GetString("user_role")) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "只能编辑自己的文章"})
+		return
+	}
 	var req struct {
 		Title   string `json:"title"`
 		Slug    string `json:"slug"`
@@ -185,6 +428,10 @@ func UpdatePost(c *gin.Context) {
 		post.Content = req.Content
 	}
 	if err = service.UpdatePost(post); err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "更新失败"})
 		return
 	}
@@ -194,6 +441,15 @@ func UpdatePost(c *gin.Context) {
 // 删除
 func DeletePost(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	post, err := service.GetPostByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "不存在"})
+		return
+	}
+	if !service.CanEditPost(post, viewerID(c), c.GetString("user_role")) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "只能删除自己的文章"})
+		return
+	}
 	if err := service.DeletePost(id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "删除失败"})
 		return
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"api/internal/modules/content/service"
+	"api/internal/modules/media"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 通过签名链接只读预览未发布内容
+// GET /api/preview/:type/:id?expires=<unix>&sig=<签名>
+func GetPreview(c *gin.Context) {
+	contentType := c.Param("type")
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 64)
+	expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+
+	if err := service.VerifyPreviewLink(contentType, id, expires, c.Query("sig")); err != nil {
+		if errors.Is(err, service.ErrPreviewUnsupported) || errors.Is(err, service.ErrPreviewInvalid) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "校验预览链接失败"})
+		}
+		return
+	}
+
+	// 预览内容不应被缓存或收录
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+
+	switch contentType {
+	case service.PreviewTypePost:
+		post, categories, tags, err := service.GetPostWithFullRelations(id)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+			return
+		}
+		// 签名只代表发布状态可放行，回收站、私密与密码校验按文章当前状态进行
+		switch service.CheckPostAccess(post, 0, "", postAccessToken(c), true) {
+		case service.PostAccessNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "文章不存在"})
+			return
+		case service.PostAccessLocked:
+			c.JSON(http.StatusForbidden, gin.H{"error": "该文章需要密码访问", "locked": true})
+			return
+		}
+		if post.CoverImage != "" {
+			post.CoverImage = media.GetFullFileURL(post.CoverImage)
+		}
+		rendered := service.RenderPostContent(post)
+		c.JSON(http.StatusOK, gin.H{
+			"type": contentType,
+			"post": gin.H{
+				"id":           post.ID,
+				"title":        post.Title,
+				"slug":         post.Slug,
+				"content":      post.Content,
+				"content_html": rendered.HTML,
+				"toc":          rendered.TOC,
+				"word_count":   rendered.WordCount,
+				"reading_time": rendered.ReadingMinutes,
+				"excerpt":      post.Excerpt,
+				"cover_image":  post.CoverImage,
+				"author_id":    post.AuthorID,
+				"status":       post.Status,
+				"published_at": post.PublishedAt,
+				"updated_at":   post.UpdatedAt,
+				"categories":   categories,
+				"tags":         tags,
+				"series":       post.Series,
+			},
+		})
+	case service.PreviewTypePage:
+		page, err := service.GetPageByID(id)
+		if err != nil || !service.CheckPageAccess(page, "", true) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "页面不存在"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"type":         contentType,
+			"page":         page,
+			"content_html": service.RenderMarkdown(page.Content).HTML,
+		})
+	case service.PreviewTypeMoment:
+		moment, err := service.GetMomentByID(id)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "动态不存在"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"type": contentType, "moment": moment})
+	}
+}
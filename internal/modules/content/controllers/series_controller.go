@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"api/internal/modules/content/service"
+	"api/internal/modules/media"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 系列列表
+func ListSeries(c *gin.Context) {
+	list, err := service.ListSeries()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
+		return
+	}
+	for i := range list {
+		if list[i].CoverImage != "" {
+			list[i].CoverImage = media.GetFullFileURL(list[i].CoverImage)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"series": list})
+}
+
+// 系列详情：按顺序返回访客可见的文章
+func GetSeries(c *gin.Context) {
+	series, err := service.GetSeriesBySlug(c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "系列不存在"})
+		return
+	}
+
+	parts, err := service.ListSeriesParts(series.ID, true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "查询失败"})
+		return
+	}
+	for i := range parts {
+		if parts[i].CoverImage != "" {
+			parts[i].CoverImage = media.GetFullFileURL(parts[i].CoverImage)
+		}
+	}
+	if series.CoverImage != "" {
+		series.CoverImage = media.GetFullFileURL(series.CoverImage)
+	}
+	series.PostCount = len(parts)
+
+	c.JSON(http.StatusOK, gin.H{"series": series, "posts": parts})
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"api/internal/modules/content/service"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 站点地图；URL 超过 50000 条时返回 sitemapindex
+// GET /sitemap.xml
+func GetSitemap(c *gin.Context) {
+	serveSitemap(c, 0)
+}
+
+// 拆分后的站点地图分片
+// GET /sitemap/:part（例如 /sitemap/1.xml）
+func GetSitemapPart(c *gin.Context) {
+	part, err := strconv.Atoi(strings.TrimSuffix(c.Param("part"), ".xml"))
+	if err != nil || part < 1 {
+		c.JSON(http.StatusNotFound, gin.H{"error": service.ErrSitemapNotFound.Error()})
+		return
+	}
+	serveSitemap(c, part)
+}
+
+func serveSitemap(c *gin.Context, part int) {
+	file, err := service.GetSitemap(part)
+	if err != nil {
+		if errors.Is(err, service.ErrSitemapNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "生成站点地图失败"})
+		}
+		return
+	}
+
+	if !file.LastModified.IsZero() {
+		c.Header("Last-Modified", file.LastModified.UTC().Format(http.TimeFormat))
+	}
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", file.Body)
+}
+
+// GET /robots.txt
+func GetRobotsTxt(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(service.RobotsTxt()))
+}
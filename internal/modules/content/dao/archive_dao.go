@@ -0,0 +1,46 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+	"time"
+)
+
+// ArchiveMonth 某年某月已发布的文章数
+type ArchiveMonth struct {
+	Year  int   `json:"year"`
+	Month int   `json:"month"`
+	Count int64 `json:"count"`
+}
+
+// ArchivePost 归档页使用的文章摘要
+type ArchivePost struct {
+	ID          uint64     `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// 按发布年月统计已发布、非私密文章数，按时间倒序
+func ListArchiveMonths() ([]ArchiveMonth, error) {
+	var rows []ArchiveMonth
+	err := database.GetDB().Model(&models.Post{}).
+		Select("YEAR(published_at) AS year, MONTH(published_at) AS month, COUNT(*) AS count").
+		Where("status = ? AND visibility <> ? AND published_at IS NOT NULL", "published", "private").
+		Group("YEAR(published_at), MONTH(published_at)").
+		Order("year DESC, month DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// 查询发布时间在 [start, end) 内的已发布、非私密文章，按发布时间倒序
+func ListArchivePosts(start, end time.Time) ([]ArchivePost, error) {
+	var rows []ArchivePost
+	err := database.GetDB().Model(&models.Post{}).
+		Select("id, title, slug, published_at").
+		Where("status = ? AND visibility <> ?", "published", "private").
+		Where("published_at >= ? AND published_at < ?", start, end).
+		Order("published_at DESC, id DESC").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,26 @@
+package dao
+
+import (
+	"api/internal/platform/db"
+	"time"
+)
+
+// AuthorPostStats 作者已发布文章统计（不含私密文章）
+type AuthorPostStats struct {
+	PostCount       int64
+	ViewCount       int64
+	LastPublishedAt *time.Time
+}
+
+// GetAuthorPostStats 统计作者公开列表中可见的已发布文章
+func GetAuthorPostStats(authorID uint64) (*AuthorPostStats, error) {
+	var stats AuthorPostStats
+	err := database.GetDB().Table("posts").
+		Select("COUNT(*) AS post_count, COALESCE(SUM(view_count), 0) AS view_count, MAX(published_at) AS last_published_at").
+		Where("author_id = ? AND status = ? AND visibility <> ?", authorID, "published", "private").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -24,6 +24,13 @@ func GetCategoryByIDFull(id uint64) (*models.Category, error) {
 		First(&cat, id).Error
 	return &cat, err
 }
+
+// 通过slug查找分类
+func GetCategoryBySlug(slug string) (*models.Category, error) {
+	var cat models.Category
+	err := database.GetDB().Where("slug = ?", slug).First(&cat).Error
+	return &cat, err
+}
 func ListCategories() ([]models.Category, error) {
 	var cats []models.Category
 	err := database.GetDB().Find(&cats).Error
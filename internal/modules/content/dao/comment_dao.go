@@ -3,6 +3,8 @@ package dao
 import (
 	"api/internal/modules/content/models"
 	"api/internal/platform/db"
+
+	"gorm.io/gorm"
 )
 
 func CreateComment(c *models.Comment) error {
@@ -24,43 +26,42 @@ func ListCommentsByPost(postID uint64) ([]models.Comment, error) {
 func UpdateComment(c *models.Comment) error {
 	return database.GetDB().Save(c).Error
 }
-func DeleteComment(id uint64) error {
-	return database.GetDB().Delete(&models.Comment{}, id).Error
-}
-
-// 批量删除评论
-func DeleteComments(ids []uint64) error {
-	if len(ids) == 0 {
-		return nil
-	}
-	return database.GetDB().Where("id IN ?", ids).Delete(&models.Comment{}).Error
-}
 
 // 统计评论总数（用于分页）
 func CountComments(postID uint64, status, q string) (int64, error) {
 	var count int64
-	db := database.GetDB().Model(&models.Comment{})
+	err := buildCommentFilterQuery(postID, status, q).Count(&count).Error
+	return count, err
+}
 
-	if postID > 0 {
-		db = db.Where("post_id = ?", postID)
-	}
+// 评论列表带分页和筛选
+func ListCommentsWithParams(page, pageSize int, postID uint64, status, q, sort string) ([]models.Comment, error) {
+	var comments []models.Comment
+	db := buildCommentFilterQuery(postID, status, q)
 
-	if status != "" {
-		db = db.Where("status = ?", status)
-	}
+	// 排序（非法值按 DESC 处理）
+	db = db.Order("created_at " + sanitizeSortOrder(sort))
 
-	if q != "" {
-		db = db.Where("content LIKE ? OR author_name LIKE ? OR author_email LIKE ?",
-			"%"+q+"%", "%"+q+"%", "%"+q+"%")
+	// 分页
+	if pageSize > 0 {
+		db = db.Limit(pageSize).Offset((page - 1) * pageSize)
 	}
 
-	err := db.Count(&count).Error
-	return count, err
+	err := db.Find(&comments).Error
+	return comments, err
 }
 
-// 评论列表带分页和筛选
-func ListCommentsWithParams(page, pageSize int, postID uint64, status, q, sort string) ([]models.Comment, error) {
+// 游标分页：按 (created_at, id) 排序取 key 之后（或之前）的最多 limit 条评论
+func ListCommentsByKeyset(postID uint64, status, q string, desc bool, key *Keyset, limit int) ([]models.Comment, error) {
 	var comments []models.Comment
+	err := applyKeyset(buildCommentFilterQuery(postID, status, q), "created_at", "id", key, desc).
+		Limit(limit).
+		Find(&comments).Error
+	return comments, err
+}
+
+// 按文章、状态、关键词筛选评论；未指定状态时不包含回收站中的评论
+func buildCommentFilterQuery(postID uint64, status, q string) *gorm.DB {
 	db := database.GetDB().Model(&models.Comment{})
 
 	if postID > 0 {
@@ -69,27 +70,15 @@ func ListCommentsWithParams(page, pageSize int, postID uint64, status, q, sort s
 
 	if status != "" {
 		db = db.Where("status = ?", status)
+	} else {
+		db = db.Where("status <> ?", "trash")
 	}
 
 	if q != "" {
 		db = db.Where("content LIKE ? OR author_name LIKE ? OR author_email LIKE ?",
 			"%"+q+"%", "%"+q+"%", "%"+q+"%")
 	}
-
-	// 排序
-	if sort != "" {
-		db = db.Order("created_at " + sort)
-	} else {
-		db = db.Order("created_at DESC")
-	}
-
-	// 分页
-	if pageSize > 0 {
-		db = db.Limit(pageSize).Offset((page - 1) * pageSize)
-	}
-
-	err := db.Find(&comments).Error
-	return comments, err
+	return db
 }
 
 // 批量更新评论状态
@@ -99,7 +88,7 @@ func UpdateCommentsStatus(ids []uint64, status string) error {
 	}
 	return database.GetDB().Model(&models.Comment{}).
 		Where("id IN ?", ids).
-		Update("status", status).Error
+		Updates(map[string]interface{}{"status": status, "trashed_from": "", "trashed_at": nil}).Error
 }
 
 // 获取评论的回复列表
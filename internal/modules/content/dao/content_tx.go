@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ContentTx 内容保存事务：修订、翻译组等附加写入与内容本身一起提交，任一步失败（包括版本冲突）整体回滚
+type ContentTx struct {
+	tx *gorm.DB
+}
+
+// RunContentTx 在一个事务中执行 fn
+func RunContentTx(fn func(t *ContentTx) error) error {
+	return database.GetDB().Transaction(func(tx *gorm.DB) error {
+		return fn(&ContentTx{tx: tx})
+	})
+}
+
+// CreatePost 新建文章
+func (t *ContentTx) CreatePost(post *models.Post) error {
+	initVersion(&post.Version)
+	return t.tx.Create(post).Error
+}
+
+// UpdatePost 按版本号保存文章
+func (t *ContentTx) UpdatePost(post *models.Post) error {
+	return saveVersioned(t.tx, post, &post.Version)
+}
+
+// CreatePostRevision 写入修订并只保留最新的 keep 条
+func (t *ContentTx) CreatePostRevision(rev *models.PostRevision, keep int) error {
+	if err := createPostRevision(t.tx, rev); err != nil {
+		return err
+	}
+	_, err := prunePostRevisions(t.tx, rev.PostID, keep)
+	return err
+}
+
+// CreatePage 新建页面
+func (t *ContentTx) CreatePage(page *models.Page) error {
+	initVersion(&page.Version)
+	return t.tx.Create(page).Error
+}
+
+// UpdatePage 按版本号保存页面
+func (t *ContentTx) UpdatePage(page *models.Page) error {
+	return saveVersioned(t.tx, page, &page.Version)
+}
+
+// ClaimTranslationGroup 为尚未加入翻译组的原文写入 group 并递增版本号，返回原文最终所在的翻译组：
+// 原文已被并发请求写入其他翻译组时返回该组。model 为 &models.Post{} 或 &models.Page{}
+func (t *ContentTx) ClaimTranslationGroup(model interface{}, id uint64, group string) (string, error) {
+	result := t.tx.Model(model).
+		Where("id = ? AND translation_group = ?", id, "").
+		Updates(bumpVersion(map[string]interface{}{"translation_group": group}))
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected > 0 {
+		return group, nil
+	}
+	var groups []string
+	err := t.tx.Model(model).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		Pluck("translation_group", &groups).Error
+	if err != nil {
+		return "", err
+	}
+	if len(groups) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return groups[0], nil
+}
+
+// TranslationLangTaken 同 TranslationLangTaken，在事务内查询
+func (t *ContentTx) TranslationLangTaken(model interface{}, group, lang string, excludeID uint64) (bool, error) {
+	return translationLangTaken(t.tx, model, group, lang, excludeID)
+}
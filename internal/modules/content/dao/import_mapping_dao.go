@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+)
+
+// ListImportMappings 查询某个来源站点的全部导入记录
+func ListImportMappings(source string) ([]models.ImportMapping, error) {
+	var mappings []models.ImportMapping
+	err := database.GetDB().Where("source = ?", source).Find(&mappings).Error
+	return mappings, err
+}
+
+func CreateImportMapping(mapping *models.ImportMapping) error {
+	return database.GetDB().Create(mapping).Error
+}
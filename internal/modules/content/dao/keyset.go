@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Keyset 游标分页的位置：上一页最后一条（或下一页第一条）记录的排序键
+type Keyset struct {
+	Time   *time.Time // 排序时间列，为 nil 表示该记录的时间为 NULL
+	ID     uint64
+	Before bool // true 表示取位于该位置之前的记录（向前翻页）
+}
+
+// KeysetRow 游标分页查询返回的排序键
+type KeysetRow struct {
+	ID       uint64
+	SortTime *time.Time
+}
+
+// applyKeyset 按 (timeCol, idCol) 排序并截取 key 之后（或之前）的记录。
+// 正向顺序为：时间非空的记录按 desc 指定方向排列，时间为 NULL 的记录排在最后并按 ID 同向排列；
+// 向前翻页时整体倒序扫描，调用方需要把结果再反转回正向顺序。
+func applyKeyset(db *gorm.DB, timeCol, idCol string, key *Keyset, desc bool) *gorm.DB {
+	forward, backward := "DESC", "ASC"
+	after, before := "<", ">"
+	if !desc {
+		forward, backward = backward, forward
+		after, before = before, after
+	}
+
+	reverse := key != nil && key.Before
+	if key != nil {
+		cmp := after
+		if reverse {
+			cmp = before
+		}
+		switch {
+		case key.Time != nil && !reverse:
+			db = db.Where("(("+timeCol+" IS NOT NULL AND ("+timeCol+" "+cmp+" ? OR ("+timeCol+" = ? AND "+idCol+" "+cmp+" ?))) OR "+timeCol+" IS NULL)",
+				*key.Time, *key.Time, key.ID)
+		case key.Time != nil && reverse:
+			db = db.Where(timeCol+" IS NOT NULL AND ("+timeCol+" "+cmp+" ? OR ("+timeCol+" = ? AND "+idCol+" "+cmp+" ?))",
+				*key.Time, *key.Time, key.ID)
+		case !reverse:
+			db = db.Where(timeCol+" IS NULL AND "+idCol+" "+cmp+" ?", key.ID)
+		default:
+			db = db.Where("("+timeCol+" IS NOT NULL OR ("+timeCol+" IS NULL AND "+idCol+" "+cmp+" ?))", key.ID)
+		}
+	}
+
+	if reverse {
+		return db.Order(timeCol + " IS NULL DESC").Order(timeCol + " " + backward).Order(idCol + " " + backward)
+	}
+	return db.Order(timeCol + " IS NULL ASC").Order(timeCol + " " + forward).Order(idCol + " " + forward)
+}
@@ -3,9 +3,11 @@ package dao
 import (
 	"api/internal/modules/content/models"
 	"api/internal/platform/db"
+	"time"
 )
 
 func CreateMoment(moment *models.Moment) error {
+	initVersion(&moment.Version)
 	return database.GetDB().Create(moment).Error
 }
 
@@ -16,7 +18,7 @@ func GetMomentByID(id uint64) (*models.Moment, error) {
 }
 
 func UpdateMoment(moment *models.Moment) error {
-	return database.GetDB().Save(moment).Error
+	return saveVersioned(database.GetDB(), moment, &moment.Version)
 }
 
 func DeleteMoment(id uint64) error {
@@ -36,3 +38,32 @@ func ListMoments(status string, limit int) ([]models.Moment, error) {
 	err := db.Find(&moments).Error
 	return moments, err
 }
+
+// ListDueScheduledMomentIDs 查询已到计划发布时间的定时动态ID
+func ListDueScheduledMomentIDs(now time.Time, limit int) ([]uint64, error) {
+	var ids []uint64
+	err := database.GetDB().Model(&models.Moment{}).
+		Where("status = ? AND published_at IS NOT NULL AND published_at <= ?", "scheduled", now).
+		Order("published_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// PublishScheduledMoment 将定时动态改为已发布，带状态条件保证幂等
+func PublishScheduledMoment(id uint64) (bool, error) {
+	result := database.GetDB().Model(&models.Moment{}).
+		Where("id = ? AND status = ?", id, "scheduled").
+		Updates(bumpVersion(map[string]interface{}{"status": "published"}))
+	return result.RowsAffected > 0, result.Error
+}
+
+// MomentExistsAt 判断是否已有创建或发布于该时间（精确到秒）的动态
+func MomentExistsAt(t time.Time) bool {
+	t = t.Truncate(time.Second)
+	var count int64
+	database.GetDB().Model(&models.Moment{}).
+		Where("created_at = ? OR published_at = ?", t, t).
+		Count(&count)
+	return count > 0
+}
@@ -6,6 +6,7 @@ import (
 )
 
 func CreatePage(page *models.Page) error {
+	initVersion(&page.Version)
 	return database.GetDB().Create(page).Error
 }
 func GetPageByID(id uint64) (*models.Page, error) {
@@ -14,10 +15,10 @@ func GetPageByID(id uint64) (*models.Page, error) {
 	return &page, err
 }
 
-// GetPageBySlug 通过slug获取页面
+// GetPageBySlug 通过slug获取页面（含草稿），是否可见由调用方判断
 func GetPageBySlug(slug string) (*models.Page, error) {
 	var page models.Page
-	err := database.GetDB().Where("slug = ? AND status = ?", slug, "published").First(&page).Error
+	err := database.GetDB().Where("slug = ?", slug).First(&page).Error
 	return &page, err
 }
 func ListPages() ([]models.Page, error) {
@@ -25,9 +26,23 @@ func ListPages() ([]models.Page, error) {
 	err := database.GetDB().Find(&pages).Error
 	return pages, err
 }
+
+// ListPublishedPages 获取已发布的页面
+func ListPublishedPages() ([]models.Page, error) {
+	var pages []models.Page
+	err := database.GetDB().Where("status = ?", "published").Find(&pages).Error
+	return pages, err
+}
 func UpdatePage(page *models.Page) error {
-	return database.GetDB().Save(page).Error
+	return saveVersioned(database.GetDB(), page, &page.Version)
 }
 func DeletePage(id uint64) error {
 	return database.GetDB().Delete(&models.Page{}, id).Error
 }
+
+// PageSlugExists 判断slug是否已被页面（含草稿）占用
+func PageSlugExists(slug string) bool {
+	var count int64
+	database.GetDB().Model(&models.Page{}).Where("slug = ?", slug).Count(&count)
+	return count > 0
+}
@@ -0,0 +1,42 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// UpsertPostAutosave 写入自动保存内容，同一用户同一篇文章只保留最新一份
+func UpsertPostAutosave(autosave *models.PostAutosave) error {
+	return database.GetDB().Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "post_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"title", "excerpt", "content", "cover_image", "base_version", "updated_at", "expires_at",
+		}),
+	}).Create(autosave).Error
+}
+
+// GetPostAutosave 查询未过期的自动保存内容
+func GetPostAutosave(userID, postID uint64, now time.Time) (*models.PostAutosave, error) {
+	var autosave models.PostAutosave
+	err := database.GetDB().
+		Where("user_id = ? AND post_id = ? AND expires_at > ?", userID, postID, now).
+		First(&autosave).Error
+	return &autosave, err
+}
+
+// DeletePostAutosave 删除用户在某篇文章上的自动保存内容
+func DeletePostAutosave(userID, postID uint64) (bool, error) {
+	result := database.GetDB().
+		Where("user_id = ? AND post_id = ?", userID, postID).
+		Delete(&models.PostAutosave{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteExpiredPostAutosaves 清理已过期的自动保存内容
+func DeleteExpiredPostAutosaves(now time.Time) (int64, error) {
+	result := database.GetDB().Where("expires_at <= ?", now).Delete(&models.PostAutosave{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,216 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrBatchPostNotFound 批量操作中的文章不存在
+var ErrBatchPostNotFound = errors.New("文章不存在")
+
+// PostBatchTx 批量操作事务内的文章写操作，同时记录涉及的分类与标签，结束时统一重算文章数
+type PostBatchTx struct {
+	tx          *gorm.DB
+	categoryIDs map[uint64]struct{}
+	tagIDs      map[uint64]struct{}
+}
+
+// RunPostBatch 在一个事务中逐篇执行 apply：每篇文章使用独立的保存点，单篇失败只回滚这一篇，
+// 失败原因按文章ID返回；全部处理完后重算涉及分类、标签的文章数，这一步失败则整个批次回滚
+func RunPostBatch(ids []uint64, apply func(b *PostBatchTx, post *models.Post) error) (map[uint64]error, error) {
+	failures := make(map[uint64]error)
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		var posts []models.Post
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Find(&posts).Error; err != nil {
+			return err
+		}
+		postMap := make(map[uint64]*models.Post, len(posts))
+		for i := range posts {
+			postMap[posts[i].ID] = &posts[i]
+		}
+
+		b := &PostBatchTx{tx: tx, categoryIDs: map[uint64]struct{}{}, tagIDs: map[uint64]struct{}{}}
+		// 状态、分类、标签的变化都会影响原有分类与标签的文章数
+		if err := b.collectTaxonomy(ids); err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			post, ok := postMap[id]
+			if !ok {
+				failures[id] = ErrBatchPostNotFound
+				continue
+			}
+			err := tx.Transaction(func(itemTx *gorm.DB) error {
+				return apply(&PostBatchTx{tx: itemTx, categoryIDs: b.categoryIDs, tagIDs: b.tagIDs}, post)
+			})
+			if err != nil {
+				failures[id] = err
+			}
+		}
+		return recountTaxonomyPosts(tx, idSetKeys(b.categoryIDs), idSetKeys(b.tagIDs))
+	})
+	return failures, err
+}
+
+func (b *PostBatchTx) collectTaxonomy(postIDs []uint64) error {
+	var categoryIDs, tagIDs []uint64
+	if err := b.tx.Model(&models.PostCategory{}).Where("post_id IN ?", postIDs).Distinct().Pluck("category_id", &categoryIDs).Error; err != nil {
+		return err
+	}
+	if err := b.tx.Model(&models.PostTag{}).Where("post_id IN ?", postIDs).Distinct().Pluck("tag_id", &tagIDs).Error; err != nil {
+		return err
+	}
+	b.touchCategories(categoryIDs)
+	b.touchTags(tagIDs)
+	return nil
+}
+
+func (b *PostBatchTx) touchCategories(ids []uint64) {
+	for _, id := range ids {
+		b.categoryIDs[id] = struct{}{}
+	}
+}
+
+func (b *PostBatchTx) touchTags(ids []uint64) {
+	for _, id := range ids {
+		b.tagIDs[id] = struct{}{}
+	}
+}
+
+// SavePost 保存文章字段变更并递增版本号
+func (b *PostBatchTx) SavePost(post *models.Post) error {
+	return saveVersioned(b.tx, post, &post.Version)
+}
+
+// AddCategories 为文章追加分类，已关联的分类跳过
+func (b *PostBatchTx) AddCategories(postID uint64, categoryIDs []uint64) error {
+	var existing []uint64
+	if err := b.tx.Model(&models.PostCategory{}).Where("post_id = ?", postID).Pluck("category_id", &existing).Error; err != nil {
+		return err
+	}
+	var rels []models.PostCategory
+	for _, id := range missingIDs(categoryIDs, existing) {
+		rels = append(rels, models.PostCategory{PostID: postID, CategoryID: id})
+	}
+	b.touchCategories(categoryIDs)
+	if len(rels) == 0 {
+		return nil
+	}
+	return b.tx.Create(&rels).Error
+}
+
+// RemoveCategories 移除文章的指定分类
+func (b *PostBatchTx) RemoveCategories(postID uint64, categoryIDs []uint64) error {
+	return b.tx.Where("post_id = ? AND category_id IN ?", postID, categoryIDs).Delete(&models.PostCategory{}).Error
+}
+
+// AddTags 为文章追加标签，已关联的标签跳过
+func (b *PostBatchTx) AddTags(postID uint64, tagIDs []uint64) error {
+	var existing []uint64
+	if err := b.tx.Model(&models.PostTag{}).Where("post_id = ?", postID).Pluck("tag_id", &existing).Error; err != nil {
+		return err
+	}
+	var rels []models.PostTag
+	for _, id := range missingIDs(tagIDs, existing) {
+		rels = append(rels, models.PostTag{PostID: postID, TagID: id})
+	}
+	b.touchTags(tagIDs)
+	if len(rels) == 0 {
+		return nil
+	}
+	return b.tx.Create(&rels).Error
+}
+
+// RemoveTags 移除文章的指定标签
+func (b *PostBatchTx) RemoveTags(postID uint64, tagIDs []uint64) error {
+	return b.tx.Where("post_id = ? AND tag_id IN ?", postID, tagIDs).Delete(&models.PostTag{}).Error
+}
+
+// RecountPostTaxonomies 重算指定文章当前所属分类、标签，以及额外给定分类、标签的文章数；
+// 额外的 ID 用于传入变更前的关联，使被移除关联的分类、标签同样得到更新
+func RecountPostTaxonomies(postIDs, categoryIDs, tagIDs []uint64) error {
+	db := database.GetDB()
+	categories := make(map[uint64]struct{})
+	tags := make(map[uint64]struct{})
+	for _, id := range categoryIDs {
+		categories[id] = struct{}{}
+	}
+	for _, id := range tagIDs {
+		tags[id] = struct{}{}
+	}
+	if len(postIDs) > 0 {
+		var current []uint64
+		if err := db.Model(&models.PostCategory{}).Where("post_id IN ?", postIDs).Distinct().Pluck("category_id", &current).Error; err != nil {
+			return err
+		}
+		for _, id := range current {
+			categories[id] = struct{}{}
+		}
+		current = nil
+		if err := db.Model(&models.PostTag{}).Where("post_id IN ?", postIDs).Distinct().Pluck("tag_id", &current).Error; err != nil {
+			return err
+		}
+		for _, id := range current {
+			tags[id] = struct{}{}
+		}
+	}
+	return recountTaxonomyPosts(db, idSetKeys(categories), idSetKeys(tags))
+}
+
+// recountTaxonomyPosts 按已发布文章重算分类、标签的 post_count；ID 列表为 nil 时重算全部，为空切片时跳过
+func recountTaxonomyPosts(db *gorm.DB, categoryIDs, tagIDs []uint64) error {
+	const categoryCount = `(SELECT COUNT(*) FROM post_categories JOIN posts ON posts.id = post_categories.post_id
+		WHERE post_categories.category_id = categories.id AND posts.status = 'published')`
+	const tagCount = `(SELECT COUNT(*) FROM post_tags JOIN posts ON posts.id = post_tags.post_id
+		WHERE post_tags.tag_id = tags.id AND posts.status = 'published')`
+
+	if categoryIDs == nil || len(categoryIDs) > 0 {
+		if err := scopeIDs(db.Model(&models.Category{}), categoryIDs).
+			UpdateColumn("post_count", gorm.Expr(categoryCount)).Error; err != nil {
+			return err
+		}
+	}
+	if tagIDs == nil || len(tagIDs) > 0 {
+		if err := scopeIDs(db.Model(&models.Tag{}), tagIDs).
+			UpdateColumn("post_count", gorm.Expr(tagCount)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scopeIDs ids 为 nil 时作用于整张表
+func scopeIDs(db *gorm.DB, ids []uint64) *gorm.DB {
+	if ids == nil {
+		return db.Session(&gorm.Session{AllowGlobalUpdate: true})
+	}
+	return db.Where("id IN ?", ids)
+}
+
+func missingIDs(want, existing []uint64) []uint64 {
+	seen := make(map[uint64]struct{}, len(existing))
+	for _, id := range existing {
+		seen[id] = struct{}{}
+	}
+	var missing []uint64
+	for _, id := range want {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func idSetKeys(set map[uint64]struct{}) []uint64 {
+	ids := make([]uint64, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -4,12 +4,14 @@ import (
 	"api/internal/modules/content/models"
 	"api/internal/platform/db"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // 新增文章
 func CreatePost(post *models.Post) error {
+	initVersion(&post.Version)
 	return database.GetDB().Create(post).Error
 }
 
@@ -27,14 +29,25 @@ func ListPosts() ([]models.Post, error) {
 	return posts, err
 }
 
+// 可见性范围（用于列表、检索的 visibility 参数）
+const (
+	PostScopeAll    = ""       // 不限可见性（管理后台）
+	PostScopeListed = "listed" // 公开 + 密码文章，私密文章不出现在公开列表
+	PostScopePublic = "public" // 仅公开文章（检索、订阅等会暴露正文的场景）
+)
+
 // 文章列表带参数
 
-func ListPostsWithParams(page int, pageSize int, q, sort, category, tag, status string) ([]models.PostWithRelations, error) {
-	postIDs, err := listOrderedPostIDs(page, pageSize, q, sort, category, tag, status)
+func ListPostsWithParams(page int, pageSize int, q, sort, category, tag, author, status, visibility, lang string) ([]models.PostWithRelations, error) {
+	postIDs, err := listOrderedPostIDs(page, pageSize, q, sort, category, tag, author, status, visibility, lang)
 	if err != nil {
 		return nil, err
 	}
+	return ListPostsByOrderedIDs(postIDs)
+}
 
+// 按给定ID顺序加载文章并补全分类、标签信息
+func ListPostsByOrderedIDs(postIDs []uint64) ([]models.PostWithRelations, error) {
 	if len(postIDs) == 0 {
 		return []models.PostWithRelations{}, nil
 	}
@@ -71,12 +84,7 @@ func sanitizeSortOrder(input string) string {
 
 // 更新
 func UpdatePost(post *models.Post) error {
-	return database.GetDB().Save(post).Error
-}
-
-// 删除
-func DeletePost(id uint64) error {
-	return database.GetDB().Delete(&models.Post{}, id).Error
+	return saveVersioned(database.GetDB(), post, &post.Version)
 }
 
 // 给文章添加分类
@@ -116,20 +124,20 @@ func BatchAddTagsToPost(postID uint64, tagIDs []uint64) error {
 }
 
 // 统计文章总数（用于分页）
-func CountPosts(q, sort, category, tag, status string) (int64, error) {
+func CountPosts(q, sort, category, tag, author, status, visibility, lang string) (int64, error) {
 	var count int64
-	db := buildPostFilterQuery(q, sort, category, tag, status)
+	db := buildPostFilterQuery(q, sort, category, tag, author, status, visibility, lang)
 	err := db.Distinct("posts.id").Count(&count).Error
 	return count, err
 }
 
-func listOrderedPostIDs(page int, pageSize int, q, sort, category, tag, status string) ([]uint64, error) {
+func listOrderedPostIDs(page int, pageSize int, q, sort, category, tag, author, status, visibility, lang string) ([]uint64, error) {
 	type postIDRow struct {
 		ID uint64
 	}
 
 	var rows []postIDRow
-	err := buildPostFilterQuery(q, sort, category, tag, status).
+	err := buildPostFilterQuery(q, sort, category, tag, author, status, visibility, lang).
 		Select("DISTINCT posts.id, posts.published_at, posts.created_at").
 		Limit(pageSize).
 		Offset((page - 1) * pageSize).
@@ -145,17 +153,49 @@ func listOrderedPostIDs(page int, pageSize int, q, sort, category, tag, status s
 	return ids, nil
 }
 
-func buildPostFilterQuery(q, sort, category, tag, status string) *gorm.DB {
+// ListPostKeysByKeyset 游标分页：按 (published_at, id) 排序取 key 之后（或之前）的最多 limit 条文章
+func ListPostKeysByKeyset(q, sort, category, tag, author, status, visibility, lang string, key *Keyset, limit int) ([]KeysetRow, error) {
+	db := buildPostScopeQuery(category, tag, author, status, visibility, lang)
+	if q = strings.TrimSpace(q); q != "" {
+		db = applyPostSearchCondition(db, q)
+	}
+	db = applyKeyset(db, "posts.published_at", "posts.id", key, sanitizeSortOrder(sort) == "DESC")
+
+	var rows []KeysetRow
+	err := db.Select("DISTINCT posts.id, posts.published_at AS sort_time").Limit(limit).Scan(&rows).Error
+	return rows, err
+}
+
+func buildPostFilterQuery(q, sort, category, tag, author, status, visibility, lang string) *gorm.DB {
+	db := buildPostScopeQuery(category, tag, author, status, visibility, lang)
+
+	q = strings.TrimSpace(q)
+	if q != "" {
+		db = applyPostSearchCondition(db, q)
+	}
+
+	orderDirection := sanitizeSortOrder(sort)
+	return db.Order("posts.published_at IS NULL ASC").
+		Order("posts.published_at " + orderDirection).
+		Order("posts.created_at " + orderDirection)
+}
+
+// 按状态、可见性、分类、标签、作者（用户名）、语言圈定文章范围（不含搜索条件与排序）
+func buildPostScopeQuery(category, tag, author, status, visibility, lang string) *gorm.DB {
 	db := database.GetDB().Model(&models.Post{})
 
 	if status != "" {
 		db = db.Where("posts.status = ?", status)
+	} else {
+		// 未指定状态时不包含回收站中的文章
+		db = db.Where("posts.status <> ?", "trash")
 	}
 
-	q = strings.TrimSpace(q)
-	if q != "" {
-		likePattern := "%" + q + "%"
-		db = db.Where("posts.title LIKE ? OR posts.excerpt LIKE ?", likePattern, likePattern)
+	switch visibility {
+	case PostScopeListed:
+		db = db.Where("posts.visibility <> ?", "private")
+	case PostScopePublic:
+		db = db.Where("posts.visibility = ?", "public")
 	}
 
 	if category != "" {
@@ -170,10 +210,14 @@ func buildPostFilterQuery(q, sort, category, tag, status string) *gorm.DB {
 			Where("tags.slug = ?", tag)
 	}
 
-	orderDirection := sanitizeSortOrder(sort)
-	return db.Order("posts.published_at IS NULL ASC").
-		Order("posts.published_at " + orderDirection).
-		Order("posts.created_at " + orderDirection)
+	if author != "" {
+		db = db.Where("posts.author_id = (SELECT id FROM users WHERE username = ?)", author)
+	}
+
+	if lang != "" {
+		db = db.Where("posts.lang = ?", lang)
+	}
+	return db
 }
 
 func hydratePostRelations(posts []models.Post) ([]models.PostWithRelations, error) {
@@ -182,8 +226,10 @@ func hydratePostRelations(posts []models.Post) ([]models.PostWithRelations, erro
 	}
 
 	postIDs := make([]uint64, 0, len(posts))
+	authorIDs := make([]uint64, 0, len(posts))
 	for _, post := range posts {
 		postIDs = append(postIDs, post.ID)
+		authorIDs = append(authorIDs, post.AuthorID)
 	}
 
 	categoryMap, err := getPostCategoryRelations(postIDs)
@@ -194,6 +240,10 @@ func hydratePostRelations(posts []models.Post) ([]models.PostWithRelations, erro
 	if err != nil {
 		return nil, err
 	}
+	authorMap, err := GetAuthorSummaries(authorIDs)
+	if err != nil {
+		return nil, err
+	}
 
 	result := make([]models.PostWithRelations, 0, len(posts))
 	for _, post := range posts {
@@ -206,6 +256,7 @@ func hydratePostRelations(posts []models.Post) ([]models.PostWithRelations, erro
 			item.TagNames = rel.names
 			item.TagIDs = rel.ids
 		}
+		item.Author = authorMap[post.AuthorID]
 		result = append(result, item)
 	}
 	return result, nil
@@ -369,3 +420,22 @@ func GetTagsByIDs(ids []uint64) ([]models.Tag, error) {
 	err := database.GetDB().Where("id IN ?", ids).Find(&tags).Error
 	return tags, err
 }
+
+// 查询已到计划发布时间的定时文章ID
+func ListDueScheduledPostIDs(now time.Time, limit int) ([]uint64, error) {
+	var ids []uint64
+	err := database.GetDB().Model(&models.Post{}).
+		Where("status = ? AND published_at IS NOT NULL AND published_at <= ?", "scheduled", now).
+		Order("published_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// 将定时文章改为已发布；带状态条件，多实例重复执行时只有一次生效
+func PublishScheduledPost(id uint64) (bool, error) {
+	result := database.GetDB().Model(&models.Post{}).
+		Where("id = ? AND status = ?", id, "scheduled").
+		Updates(bumpVersion(map[string]interface{}{"status": "published"}))
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,56 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+	"time"
+)
+
+// 相关文章计算时截取的正文长度，避免一次性加载全部长文
+const relatedContentPrefix = 4000
+
+// RelatedCandidate 参与相关文章计算的文章
+type RelatedCandidate struct {
+	ID          uint64
+	Title       string
+	Excerpt     string
+	Content     string
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+}
+
+// 查询可作为相关推荐的文章：已发布且非私密
+func ListRelatedCandidates() ([]RelatedCandidate, error) {
+	var rows []RelatedCandidate
+	err := database.GetDB().Model(&models.Post{}).
+		Select("id, title, excerpt, LEFT(content, ?) AS content, published_at, created_at", relatedContentPrefix).
+		Where("status = ? AND visibility <> ?", "published", "private").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// 查询全部文章-标签关联，返回 post_id -> tag_id 列表
+func ListAllPostTagIDs() (map[uint64][]uint64, error) {
+	var rows []models.PostTag
+	if err := database.GetDB().Select("post_id, tag_id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint64][]uint64)
+	for _, row := range rows {
+		result[row.PostID] = append(result[row.PostID], row.TagID)
+	}
+	return result, nil
+}
+
+// 查询全部文章-分类关联，返回 post_id -> category_id 列表
+func ListAllPostCategoryIDs() (map[uint64][]uint64, error) {
+	var rows []models.PostCategory
+	if err := database.GetDB().Select("post_id, category_id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint64][]uint64)
+	for _, row := range rows {
+		result[row.PostID] = append(result[row.PostID], row.CategoryID)
+	}
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+
+	"gorm.io/gorm"
+)
+
+// createPostRevision 写入一条修订记录，RevisionNo 在文章内自增
+func createPostRevision(db *gorm.DB, rev *models.PostRevision) error {
+	var maxNo int
+	err := db.Model(&models.PostRevision{}).
+		Where("post_id = ?", rev.PostID).
+		Select("COALESCE(MAX(revision_no), 0)").
+		Scan(&maxNo).Error
+	if err != nil {
+		return err
+	}
+	rev.RevisionNo = maxNo + 1
+	return db.Create(rev).Error
+}
+
+// ListPostRevisions 获取文章的修订列表（不含正文，按序号倒序）
+func ListPostRevisions(postID uint64) ([]models.PostRevision, error) {
+	var revisions []models.PostRevision
+	err := database.GetDB().
+		Select("id, post_id, revision_no, title, excerpt, editor_id, reason, created_at").
+		Where("post_id = ?", postID).
+		Order("revision_no DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// GetPostRevision 获取文章下的单个修订（含正文）
+func GetPostRevision(postID, revisionID uint64) (*models.PostRevision, error) {
+	var rev models.PostRevision
+	err := database.GetDB().
+		Where("id = ? AND post_id = ?", revisionID, postID).
+		First(&rev).Error
+	return &rev, err
+}
+
+// prunePostRevisions 只保留最新的 keep 条修订，返回删除数量
+func prunePostRevisions(db *gorm.DB, postID uint64, keep int) (int64, error) {
+	if keep <= 0 {
+		return 0, nil
+	}
+	var ids []uint64
+	err := db.Model(&models.PostRevision{}).
+		Where("post_id = ?", postID).
+		Order("revision_no DESC").
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) <= keep {
+		return 0, err
+	}
+	result := db.Where("id IN ?", ids[keep:]).Delete(&models.PostRevision{})
+	return result.RowsAffected, result.Error
+}
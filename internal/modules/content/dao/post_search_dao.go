@@ -0,0 +1,110 @@
+package dao
+
+import (
+	"api/internal/platform/db"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// posts 表上的 FULLTEXT 索引（WITH PARSER ngram），覆盖 title/excerpt/content
+const postFullTextIndex = "idx_posts_fulltext"
+
+// ngram 默认 token 长度为 2，短于此长度的关键词无法通过全文索引命中
+const minFullTextQueryRunes = 2
+
+const postMatchExpr = "MATCH(posts.title, posts.excerpt, posts.content) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+// LIKE 兜底时的相关度：标题命中权重最高，其次摘要、正文
+const postLikeScoreExpr = "(CASE WHEN posts.title LIKE ? THEN 3 ELSE 0 END + " +
+	"CASE WHEN posts.excerpt LIKE ? THEN 2 ELSE 0 END + " +
+	"CASE WHEN posts.content LIKE ? THEN 1 ELSE 0 END)"
+
+var (
+	fullTextOnce    sync.Once
+	fullTextEnabled bool
+)
+
+// PostSearchHit 检索命中的文章ID及相关度分数
+type PostSearchHit struct {
+	ID    uint64
+	Score float64
+}
+
+// PostFullTextEnabled 检查 posts 表是否已建立全文索引（结果在进程内缓存）。
+// 老库需要先执行 database/sql/post_fulltext_search.sql，否则检索会退化为 LIKE。
+func PostFullTextEnabled() bool {
+	fullTextOnce.Do(func() {
+		var count int64
+		err := database.GetDB().Raw(
+			"SELECT COUNT(*) FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ? AND INDEX_TYPE = 'FULLTEXT'",
+			"posts", postFullTextIndex,
+		).Scan(&count).Error
+		if err != nil {
+			fmt.Printf("[search] 检查全文索引失败，使用 LIKE 检索: %v\n", err)
+			return
+		}
+		fullTextEnabled = count > 0
+		if !fullTextEnabled {
+			fmt.Printf("[search] 未找到全文索引 %s，使用 LIKE 检索\n", postFullTextIndex)
+		}
+	})
+	return fullTextEnabled
+}
+
+func useFullText(q string) bool {
+	return utf8.RuneCountInString(q) >= minFullTextQueryRunes && PostFullTextEnabled()
+}
+
+// applyPostSearchCondition 为查询追加关键词条件：优先全文索引，否则对标题、摘要、正文做 LIKE
+func applyPostSearchCondition(db *gorm.DB, q string) *gorm.DB {
+	if useFullText(q) {
+		return db.Where(postMatchExpr, q)
+	}
+	likePattern := "%" + escapeLike(q) + "%"
+	return db.Where("posts.title LIKE ? OR posts.excerpt LIKE ? OR posts.content LIKE ?", likePattern, likePattern, likePattern)
+}
+
+// SearchPosts 按相关度检索文章，返回当前页命中及命中总数
+func SearchPosts(page, pageSize int, q, category, tag, author, status, visibility, lang string) ([]PostSearchHit, int64, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return []PostSearchHit{}, 0, nil
+	}
+
+	var total int64
+	countQuery := applyPostSearchCondition(buildPostScopeQuery(category, tag, author, status, visibility, lang), q)
+	if err := countQuery.Distinct("posts.id").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []PostSearchHit{}, 0, nil
+	}
+
+	query := applyPostSearchCondition(buildPostScopeQuery(category, tag, author, status, visibility, lang), q)
+	if useFullText(q) {
+		query = query.Select("DISTINCT posts.id, "+postMatchExpr+" AS score, posts.published_at", q)
+	} else {
+		likePattern := "%" + escapeLike(q) + "%"
+		query = query.Select("DISTINCT posts.id, "+postLikeScoreExpr+" AS score, posts.published_at", likePattern, likePattern, likePattern)
+	}
+
+	var hits []PostSearchHit
+	err := query.
+		Order("score DESC").
+		Order("posts.published_at DESC").
+		Order("posts.id DESC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Scan(&hits).Error
+	return hits, total, err
+}
+
+// escapeLike 转义 LIKE 通配符，避免用户输入的 % 和 _ 被当作通配符
+func escapeLike(q string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(q)
+}
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+
+	"gorm.io/gorm/clause"
+)
+
+// 记录文章的历史slug；同一slug曾属于其他文章时改为指向当前文章
+func SavePostSlugHistory(postID uint64, slug string) error {
+	record := models.PostSlugHistory{PostID: postID, Slug: slug}
+	return database.GetDB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"post_id", "created_at"}),
+	}).Create(&record).Error
+}
+
+// 通过历史slug查找记录
+func GetPostSlugHistory(slug string) (*models.PostSlugHistory, error) {
+	var record models.PostSlugHistory
+	err := database.GetDB().Where("slug = ?", slug).First(&record).Error
+	return &record, err
+}
+
+// 查询文章的历史slug（新的在前）
+func ListPostSlugHistory(postID uint64) ([]models.PostSlugHistory, error) {
+	var records []models.PostSlugHistory
+	err := database.GetDB().Where("post_id = ?", postID).Order("created_at DESC").Find(&records).Error
+	return records, err
+}
+
+// 删除某个历史slug（文章改回旧slug时）
+func DeletePostSlugHistory(slug string) error {
+	return database.GetDB().Where("slug = ?", slug).Delete(&models.PostSlugHistory{}).Error
+}
+
+// 检查slug是否是其他文章的历史slug
+func PostSlugInHistory(slug string, excludeID uint64) bool {
+	var count int64
+	db := database.GetDB().Model(&models.PostSlugHistory{}).Where("slug = ?", slug)
+	if excludeID > 0 {
+		db = db.Where("post_id != ?", excludeID)
+	}
+	db.Count(&count)
+	return count > 0
+}
+
+// 仅查询文章当前slug
+func GetPostSlug(postID uint64) (string, error) {
+	var slugs []string
+	err := database.GetDB().Model(&models.Post{}).Where("id = ?", postID).Pluck("slug", &slugs).Error
+	if err != nil || len(slugs) == 0 {
+		return "", err
+	}
+	return slugs[0], nil
+}
@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 获取内容的预览密钥，不存在时返回空字符串
+func GetPreviewNonce(contentType string, contentID uint64) (string, error) {
+	var key models.PreviewKey
+	err := database.GetDB().
+		Where("content_type = ? AND content_id = ?", contentType, contentID).
+		First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return key.Nonce, err
+}
+
+// 获取内容的预览密钥，不存在时以 nonce 创建
+func GetOrCreatePreviewNonce(contentType string, contentID uint64, nonce string) (string, error) {
+	key := models.PreviewKey{ContentType: contentType, ContentID: contentID}
+	err := database.GetDB().
+		Where("content_type = ? AND content_id = ?", contentType, contentID).
+		Attrs(models.PreviewKey{Nonce: nonce}).
+		FirstOrCreate(&key).Error
+	return key.Nonce, err
+}
+
+// 轮换内容的预览密钥（不存在时创建）
+func RotatePreviewNonce(contentType string, contentID uint64, nonce string) error {
+	key := models.PreviewKey{ContentType: contentType, ContentID: contentID, Nonce: nonce}
+	return database.GetDB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "content_type"}, {Name: "content_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nonce", "updated_at"}),
+	}).Create(&key).Error
+}
+
+// 删除内容的预览密钥
+func DeletePreviewKey(contentType string, contentID uint64) error {
+	return database.GetDB().
+		Where("content_type = ? AND content_id = ?", contentType, contentID).
+		Delete(&models.PreviewKey{}).Error
+}
@@ -0,0 +1,148 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+
+	"gorm.io/gorm"
+)
+
+func CreateSeries(series *models.Series) error {
+	return database.GetDB().Create(series).Error
+}
+
+func GetSeriesByID(id uint64) (*models.Series, error) {
+	var series models.Series
+	err := database.GetDB().First(&series, id).Error
+	return &series, err
+}
+
+func GetSeriesBySlug(slug string) (*models.Series, error) {
+	var series models.Series
+	err := database.GetDB().Where("slug = ?", slug).First(&series).Error
+	return &series, err
+}
+
+// 系列列表（附带文章数）
+func ListSeries() ([]models.Series, error) {
+	var list []models.Series
+	if err := database.GetDB().Order("id DESC").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return list, nil
+	}
+
+	type countRow struct {
+		SeriesID uint64
+		Total    int
+	}
+	var rows []countRow
+	err := database.GetDB().Model(&models.SeriesPost{}).
+		Select("series_id, COUNT(*) AS total").
+		Group("series_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[uint64]int, len(rows))
+	for _, row := range rows {
+		counts[row.SeriesID] = row.Total
+	}
+	for i := range list {
+		list[i].PostCount = counts[list[i].ID]
+	}
+	return list, nil
+}
+
+func UpdateSeries(series *models.Series) error {
+	return database.GetDB().Save(series).Error
+}
+
+// 删除系列及其文章关联
+func DeleteSeries(id uint64) error {
+	return database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", id).Delete(&models.SeriesPost{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Series{}, id).Error
+	})
+}
+
+// 检查系列slug是否已存在
+func SeriesSlugExists(slug string, excludeID uint64) bool {
+	var count int64
+	db := database.GetDB().Model(&models.Series{}).Where("slug = ?", slug)
+	if excludeID > 0 {
+		db = db.Where("id != ?", excludeID)
+	}
+	db.Count(&count)
+	return count > 0
+}
+
+// 按给定顺序重设系列文章；已属于其他系列的文章会被移入当前系列，原系列在同一事务中重新编号
+func ReplaceSeriesPosts(seriesID uint64, postIDs []uint64) error {
+	return database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", seriesID).Delete(&models.SeriesPost{}).Error; err != nil {
+			return err
+		}
+		if len(postIDs) == 0 {
+			return nil
+		}
+		var sourceIDs []uint64
+		if err := tx.Model(&models.SeriesPost{}).Where("post_id IN ?", postIDs).Distinct().Pluck("series_id", &sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("post_id IN ?", postIDs).Delete(&models.SeriesPost{}).Error; err != nil {
+			return err
+		}
+		for _, sourceID := range sourceIDs {
+			if err := renumberSeriesPosts(tx, sourceID); err != nil {
+				return err
+			}
+		}
+		rels := make([]models.SeriesPost, 0, len(postIDs))
+		for i, postID := range postIDs {
+			rels = append(rels, models.SeriesPost{SeriesID: seriesID, PostID: postID, Position: i + 1})
+		}
+		return tx.Create(&rels).Error
+	})
+}
+
+// renumberSeriesPosts 按原有顺序把系列文章的序号重排为 1..n，避免移出文章后留下空缺影响上一篇/下一篇导航
+func renumberSeriesPosts(tx *gorm.DB, seriesID uint64) error {
+	var rels []models.SeriesPost
+	if err := tx.Where("series_id = ?", seriesID).Order("position ASC, id ASC").Find(&rels).Error; err != nil {
+		return err
+	}
+	for i := range rels {
+		if rels[i].Position == i+1 {
+			continue
+		}
+		if err := tx.Model(&models.SeriesPost{}).Where("id = ?", rels[i].ID).Update("position", i+1).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 查询系列中的文章（按顺序）；publicOnly 时只返回已发布且非私密的文章，includePostID 始终保留
+func ListSeriesParts(seriesID uint64, publicOnly bool, includePostID uint64) ([]models.SeriesPart, error) {
+	var parts []models.SeriesPart
+	db := database.GetDB().Table("series_posts").
+		Select("series_posts.post_id, series_posts.position, posts.title, posts.slug, posts.excerpt, posts.cover_image, posts.status, posts.visibility, posts.published_at").
+		Joins("JOIN posts ON posts.id = series_posts.post_id").
+		Where("series_posts.series_id = ?", seriesID)
+	if publicOnly {
+		db = db.Where("(posts.status = ? AND posts.visibility <> ?) OR posts.id = ?", "published", "private", includePostID)
+	}
+	err := db.Order("series_posts.position ASC").Scan(&parts).Error
+	return parts, err
+}
+
+// 查询文章所属的系列关联，不属于任何系列时返回 gorm.ErrRecordNotFound
+func GetSeriesPostByPostID(postID uint64) (*models.SeriesPost, error) {
+	var rel models.SeriesPost
+	err := database.GetDB().Where("post_id = ?", postID).First(&rel).Error
+	return &rel, err
+}
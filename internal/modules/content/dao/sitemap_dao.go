@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+	"time"
+)
+
+// SitemapEntry 站点地图条目：Key 为文章、页面、分类、标签的 slug；
+// Lang 与 TranslationGroup 仅文章和页面有值，用于生成 hreflang 备用链接
+type SitemapEntry struct {
+	Key              string
+	UpdatedAt        time.Time
+	Lang             string
+	TranslationGroup string
+}
+
+// 查询已发布、公开可见且允许收录的文章
+func ListSitemapPosts() ([]SitemapEntry, error) {
+	var rows []SitemapEntry
+	err := database.GetDB().Model(&models.Post{}).
+		Select("slug AS `key`, updated_at, lang, translation_group").
+		Where("status = ? AND visibility = ? AND noindex = ?", "published", "public", false).
+		Order("id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// 查询已发布且允许收录的页面
+func ListSitemapPages() ([]SitemapEntry, error) {
+	var rows []SitemapEntry
+	err := database.GetDB().Model(&models.Page{}).
+		Select("slug AS `key`, updated_at, lang, translation_group").
+		Where("status = ? AND noindex = ?", "published", false).
+		Order("menu_order ASC, id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// 查询分类，lastmod 取分类本身与其下最新公开文章更新时间的较大者
+func ListSitemapCategories() ([]SitemapEntry, error) {
+	var rows []SitemapEntry
+	err := database.GetDB().Table("categories").
+		Select("categories.slug AS `key`, GREATEST(categories.updated_at, COALESCE(MAX(posts.updated_at), categories.updated_at)) AS updated_at").
+		Joins("LEFT JOIN post_categories ON post_categories.category_id = categories.id").
+		Joins("LEFT JOIN posts ON posts.id = post_categories.post_id AND posts.status = ? AND posts.visibility = ?", "published", "public").
+		Group("categories.id, categories.slug, categories.updated_at").
+		Order("categories.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// 查询标签；标签没有更新时间，lastmod 取创建时间与其下最新公开文章更新时间的较大者
+func ListSitemapTags() ([]SitemapEntry, error) {
+	var rows []SitemapEntry
+	err := database.GetDB().Table("tags").
+		Select("tags.slug AS `key`, GREATEST(tags.created_at, COALESCE(MAX(posts.updated_at), tags.created_at)) AS updated_at").
+		Joins("LEFT JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("LEFT JOIN posts ON posts.id = post_tags.post_id AND posts.status = ? AND posts.visibility = ?", "published", "public").
+		Group("tags.id, tags.slug, tags.created_at").
+		Order("tags.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -13,6 +13,13 @@ func GetTagByID(id uint64) (*models.Tag, error) {
 	err := database.GetDB().First(&tag, id).Error
 	return &tag, err
 }
+
+// 通过slug查找标签
+func GetTagBySlug(slug string) (*models.Tag, error) {
+	var tag models.Tag
+	err := database.GetDB().Where("slug = ?", slug).First(&tag).Error
+	return &tag, err
+}
 func ListTags() ([]models.Tag, error) {
 	var tags []models.Tag
 	err := database.GetDB().Find(&tags).Error
@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+
+	"gorm.io/gorm"
+)
+
+// ListPostTranslations 查询翻译组中的文章（不含回收站）；publicOnly 时只包含已发布且公开可见的文章
+func ListPostTranslations(group string, publicOnly bool) ([]models.TranslationLink, error) {
+	var links []models.TranslationLink
+	db := database.GetDB().Model(&models.Post{}).
+		Select("id, lang, slug, title").
+		Where("translation_group = ? AND status <> ?", group, "trash")
+	if publicOnly {
+		db = db.Where("status = ? AND visibility = ?", "published", "public")
+	}
+	err := db.Order("lang ASC, id ASC").Scan(&links).Error
+	return links, err
+}
+
+// ListPageTranslations 查询翻译组中的页面；publishedOnly 时只包含已发布的页面
+func ListPageTranslations(group string, publishedOnly bool) ([]models.TranslationLink, error) {
+	var links []models.TranslationLink
+	db := database.GetDB().Model(&models.Page{}).
+		Select("id, lang, slug, title").
+		Where("translation_group = ?", group)
+	if publishedOnly {
+		db = db.Where("status = ?", "published")
+	}
+	err := db.Order("lang ASC, id ASC").Scan(&links).Error
+	return links, err
+}
+
+// TranslationLangTaken 判断翻译组中除 excludeID 外是否已有该语言的版本（回收站中的文章同样占用），model 为 &models.Post{} 或 &models.Page{}
+func TranslationLangTaken(model interface{}, group, lang string, excludeID uint64) (bool, error) {
+	return translationLangTaken(database.GetDB(), model, group, lang, excludeID)
+}
+
+func translationLangTaken(db *gorm.DB, model interface{}, group, lang string, excludeID uint64) (bool, error) {
+	var count int64
+	err := db.Model(model).
+		Where("translation_group = ? AND lang = ? AND id <> ?", group, lang, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// TranslationMember 翻译组成员，用于批量生成 hreflang 备用链接
+type TranslationMember struct {
+	models.TranslationLink
+	TranslationGroup string
+}
+
+// ListPublicPostTranslations 批量查询多个翻译组中已发布且公开可见的文章
+func ListPublicPostTranslations(groups []string) ([]TranslationMember, error) {
+	if len(groups) == 0 {
+		return []TranslationMember{}, nil
+	}
+	var members []TranslationMember
+	err := database.GetDB().Model(&models.Post{}).
+		Select("id, lang, slug, title, translation_group").
+		Where("translation_group IN ? AND status = ? AND visibility = ?", groups, "published", "public").
+		Order("lang ASC, id ASC").
+		Scan(&members).Error
+	return members, err
+}
+
+// PublicPostLangExists 是否有该语言的已发布且公开可见的文章
+func PublicPostLangExists(lang string) (bool, error) {
+	var ids []uint64
+	err := database.GetDB().Model(&models.Post{}).
+		Where("lang = ? AND status = ? AND visibility = ?", lang, "published", "public").
+		Limit(1).
+		Pluck("id", &ids).Error
+	return len(ids) > 0, err
+}
@@ -0,0 +1,189 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const trashStatus = "trash"
+
+// 移入回收站前可能的状态，恢复时按原状态分组更新
+var (
+	postTrashableStatuses    = []string{"published", "draft", "pending", "scheduled"}
+	commentTrashableStatuses = []string{"approved", "pending", "spam"}
+)
+
+// TrashPosts 将文章移入回收站并记录原状态，已在回收站中的文章不受影响，返回实际移入的数量
+func TrashPosts(ids []uint64, now time.Time) (int64, error) {
+	return moveToTrash(&models.Post{}, ids, postTrashableStatuses, now, true)
+}
+
+// RestorePosts 将回收站中的文章恢复为移入前的状态（无记录时恢复为草稿）
+func RestorePosts(ids []uint64) (int64, error) {
+	return restoreFromTrash(&models.Post{}, ids, postTrashableStatuses, "draft", true)
+}
+
+// TrashComments 将评论移入回收站并记录原状态
+func TrashComments(ids []uint64, now time.Time) (int64, error) {
+	return moveToTrash(&models.Comment{}, ids, commentTrashableStatuses, now, false)
+}
+
+// RestoreComments 将回收站中的评论恢复为移入前的状态（无记录时恢复为待审核）
+func RestoreComments(ids []uint64) (int64, error) {
+	return restoreFromTrash(&models.Comment{}, ids, commentTrashableStatuses, "pending", false)
+}
+
+// 按原状态分组更新，避免在同一条 UPDATE 中依赖赋值顺序读取旧的 status；
+// 使用 UpdateColumns 不改动 updated_at，它仍表示内容的最后编辑时间；versioned 表示该表带版本号
+func moveToTrash(model interface{}, ids []uint64, statuses []string, now time.Time, versioned bool) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	var moved int64
+	for _, status := range statuses {
+		values := map[string]interface{}{
+			"status":       trashStatus,
+			"trashed_from": status,
+			"trashed_at":   now,
+		}
+		if versioned {
+			bumpVersion(values)
+		}
+		result := database.GetDB().Model(model).
+			Where("id IN ? AND status = ?", ids, status).
+			UpdateColumns(values)
+		if result.Error != nil {
+			return moved, result.Error
+		}
+		moved += result.RowsAffected
+	}
+	return moved, nil
+}
+
+func restoreFromTrash(model interface{}, ids []uint64, statuses []string, fallback string, versioned bool) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	assignments := func(status string) map[string]interface{} {
+		values := map[string]interface{}{"status": status, "trashed_from": "", "trashed_at": nil}
+		if versioned {
+			bumpVersion(values)
+		}
+		return values
+	}
+	var restored int64
+	for _, status := range statuses {
+		result := database.GetDB().Model(model).
+			Where("id IN ? AND status = ? AND trashed_from = ?", ids, trashStatus, status).
+			UpdateColumns(assignments(status))
+		if result.Error != nil {
+			return restored, result.Error
+		}
+		restored += result.RowsAffected
+	}
+	// 直接改为 trash 状态或升级前就在回收站中的内容没有原状态记录
+	result := database.GetDB().Model(model).
+		Where("id IN ? AND status = ?", ids, trashStatus).
+		UpdateColumns(assignments(fallback))
+	if result.Error != nil {
+		return restored, result.Error
+	}
+	return restored + result.RowsAffected, nil
+}
+
+// ListTrashedPostIDs 查询移入回收站早于 before 的文章ID；before 为零值时返回回收站中的全部文章。
+// 没有 trashed_at 记录的旧数据按 updated_at 计算。
+func ListTrashedPostIDs(before time.Time, limit int) ([]uint64, error) {
+	return listTrashedIDs(&models.Post{}, before, limit)
+}
+
+// ListTrashedCommentIDs 查询移入回收站早于 before 的评论ID，规则同 ListTrashedPostIDs
+func ListTrashedCommentIDs(before time.Time, limit int) ([]uint64, error) {
+	return listTrashedIDs(&models.Comment{}, before, limit)
+}
+
+func listTrashedIDs(model interface{}, before time.Time, limit int) ([]uint64, error) {
+	var ids []uint64
+	db := database.GetDB().Model(model).Where("status = ?", trashStatus)
+	if !before.IsZero() {
+		db = db.Where("COALESCE(trashed_at, updated_at) < ?", before)
+	}
+	err := db.Order("id ASC").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// PurgePost 在一个事务中彻底删除回收站中的文章及其分类、标签、修订、评论、点赞等关联数据；
+// 文章已不在回收站时不做任何操作，返回 false
+func PurgePost(id uint64) (bool, error) {
+	purged := false
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		var post models.Post
+		if err := tx.Select("id").Where("id = ? AND status = ?", id, trashStatus).First(&post).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		commentIDs := tx.Model(&models.Comment{}).Select("id").Where("post_id = ?", id)
+		if err := tx.Where("post_id = ? OR comment_id IN (?)", id, commentIDs).Delete(&models.Like{}).Error; err != nil {
+			return err
+		}
+		dependents := []interface{}{
+			&models.Comment{},
+			&models.PostCategory{},
+			&models.PostTag{},
+			&models.PostRevision{},
+			&models.PostAutosave{},
+			&models.PostSlugHistory{},
+			&models.PostViewStat{},
+			&models.SeriesPost{},
+		}
+		for _, model := range dependents {
+			if err := tx.Where("post_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("content_type = ? AND content_id = ?", "post", id).Delete(&models.PreviewKey{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Post{}, id).Error; err != nil {
+			return err
+		}
+		purged = true
+		return nil
+	})
+	return purged, err
+}
+
+// PurgeComment 在一个事务中彻底删除回收站中的评论及其点赞，它的回复改挂到上一级评论下
+func PurgeComment(id uint64) (bool, error) {
+	purged := false
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		var comment models.Comment
+		if err := tx.Where("id = ? AND status = ?", id, trashStatus).First(&comment).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if err := tx.Model(&models.Comment{}).Where("parent_id = ?", id).
+			UpdateColumn("parent_id", comment.ParentID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id = ?", id).Delete(&models.Like{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Comment{}, id).Error; err != nil {
+			return err
+		}
+		purged = true
+		return nil
+	})
+	return purged, err
+}
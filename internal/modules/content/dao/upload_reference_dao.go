@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"api/internal/modules/content/models"
+	"api/internal/platform/db"
+
+	"gorm.io/gorm"
+)
+
+// ListRevisionContents 查询全部修订的正文（用于统计上传文件引用），分批读取避免一次加载过多
+func ListRevisionContents(fn func(revisions []models.PostRevision) error) error {
+	var batch []models.PostRevision
+	return database.GetDB().
+		Select("id, post_id, revision_no, title, content").
+		FindInBatches(&batch, 200, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+// ListPostAutosaves 查询全部自动保存内容（含已过期但尚未清理的记录）
+func ListPostAutosaves() ([]models.PostAutosave, error) {
+	var autosaves []models.PostAutosave
+	err := database.GetDB().Find(&autosaves).Error
+	return autosaves, err
+}
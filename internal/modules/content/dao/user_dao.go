@@ -32,6 +32,33 @@ func GetUserByUsernameOrEmail(username, email string) (*models.User, error) {
 	return &user, err
 }
 
+// 通过用户名查找
+func GetUserByUsername(username string) (*models.User, error) {
+	var user models.User
+	err := database.GetDB().Where("username = ?", username).First(&user).Error
+	return &user, err
+}
+
+// GetAuthorSummaries 批量查询作者公开信息，按用户ID索引；已删除的用户不出现在结果中
+func GetAuthorSummaries(ids []uint64) (map[uint64]*models.AuthorSummary, error) {
+	result := make(map[uint64]*models.AuthorSummary, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	var authors []models.AuthorSummary
+	err := database.GetDB().Model(&models.User{}).
+		Select("id, username, display_name, avatar_url").
+		Where("id IN ?", ids).
+		Scan(&authors).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range authors {
+		result[authors[i].ID] = &authors[i]
+	}
+	return result, nil
+}
+
 // 更新用户
 func UpdateUser(user *models.User) error {
 	return database.GetDB().Save(user).Error
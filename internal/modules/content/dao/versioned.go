@@ -0,0 +1,38 @@
+package dao
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict 记录在读取之后已被其他请求修改
+var ErrVersionConflict = errors.New("内容已被其他人修改，请刷新后重试")
+
+// initVersion 新建记录从版本 1 开始
+func initVersion(version *uint64) {
+	if *version == 0 {
+		*version = 1
+	}
+}
+
+// saveVersioned 以读取时的版本号为条件保存整条记录，成功后版本号加一；
+// 期间记录已被其他请求修改时不写入并返回 ErrVersionConflict
+func saveVersioned(db *gorm.DB, value interface{}, version *uint64) error {
+	expected := *version
+	*version = expected + 1
+	result := db.Model(value).Select("*").Where("version = ?", expected).Updates(value)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = expected
+	}
+	return result.Error
+}
+
+// bumpVersion 整列更新（状态流转等）时一并递增版本号，让编辑中的旧副本无法覆盖这次修改
+func bumpVersion(values map[string]interface{}) map[string]interface{} {
+	values["version"] = gorm.Expr("version + 1")
+	return values
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ImportMapping 外部站点导入记录 - 原站对象ID与本站对象的对应关系，重复导入时据此跳过已导入的数据
+type ImportMapping struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement;comment:记录ID" json:"id"`
+	Source    string    `gorm:"size:191;not null;uniqueIndex:idx_import_mapping_key,priority:1;comment:来源站点地址" json:"source"`
+	Kind      string    `gorm:"size:20;not null;uniqueIndex:idx_import_mapping_key,priority:2;comment:对象类型(category/tag/attachment/post/page/comment)" json:"kind"`
+	SourceID  string    `gorm:"size:64;not null;uniqueIndex:idx_import_mapping_key,priority:3;comment:原站对象ID" json:"source_id"`
+	LocalID   uint64    `gorm:"not null;default:0;comment:本站对象ID" json:"local_id"`
+	LocalURL  string    `gorm:"size:500;comment:本站文件地址(附件)" json:"local_url"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:导入时间" json:"created_at"`
+}
+
+func (ImportMapping) TableName() string { return "import_mappings" }
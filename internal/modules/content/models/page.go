@@ -15,6 +15,16 @@ type Page struct {
 	ParentID  *uint64   `gorm:"index;comment:父页面ID" json:"parent_id"`
 	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+	Version   uint64    `gorm:"not null;default:1;comment:版本号(乐观锁)" json:"version"`
+
+	// SEO 元数据
+	SEOMeta
+
+	// 语言与翻译组
+	TranslationMeta
+
+	// 其他语言版本，仅详情接口填充
+	Translations []TranslationLink `json:"translations,omitempty" gorm:"-"`
 }
 
 func (Page) TableName() string { return "pages" }
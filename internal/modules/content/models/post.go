@@ -8,28 +8,46 @@ import (
 // 多对多: Categories/Tags
 type Post struct {
 	ID            uint64     `gorm:"primaryKey;autoIncrement;comment:文章唯一ID" json:"id"`
-	Title         string     `gorm:"size:200;not null;comment:标题" json:"title"`
+	Title         string     `gorm:"size:200;not null;index:idx_posts_fulltext,class:FULLTEXT,option:WITH PARSER ngram,priority:1;comment:标题" json:"title"`
 	Slug          string     `gorm:"size:200;not null;uniqueIndex;comment:URL标识" json:"slug"`
-	Excerpt       string     `gorm:"type:text;comment:文章摘要" json:"excerpt"`
-	Content       string     `gorm:"type:longtext;not null;comment:文章内容" json:"content"`
+	Excerpt       string     `gorm:"type:text;index:idx_posts_fulltext,class:FULLTEXT,option:WITH PARSER ngram,priority:2;comment:文章摘要" json:"excerpt"`
+	Content       string     `gorm:"type:longtext;not null;index:idx_posts_fulltext,class:FULLTEXT,option:WITH PARSER ngram,priority:3;comment:文章内容" json:"content"`
 	CoverImage    string     `gorm:"size:255;comment:封面图片URL" json:"cover_image"`
 	AuthorID      uint64     `gorm:"index;not null;comment:作者用户ID" json:"author_id"`
-	Status        string     `gorm:"type:enum('published','draft','pending','trash');default:'draft';comment:状态" json:"status"`
+	Status        string     `gorm:"type:enum('published','draft','pending','scheduled','trash');default:'draft';comment:状态" json:"status"`
 	Visibility    string     `gorm:"type:enum('public','private','password');default:'public';comment:可见性" json:"visibility"`
-	Password      string     `gorm:"size:100;comment:访问密码" json:"password"`
+	Password      string     `gorm:"size:100;comment:访问密码(bcrypt哈希)" json:"-"`
 	CommentStatus string     `gorm:"type:enum('open','closed');default:'open';comment:评论状态" json:"comment_status"`
 	ViewCount     int        `gorm:"default:0;comment:阅读数" json:"view_count"`
 	LikeCount     int        `gorm:"default:0;comment:点赞数量" json:"like_count"`
 	CommentCount  int        `gorm:"default:0;comment:评论数量" json:"comment_count"`
-	PublishedAt   *time.Time `gorm:"index;comment:发布时间" json:"published_at"`
+	PublishedAt   *time.Time `gorm:"index;comment:发布时间(定时发布时为计划时间)" json:"published_at"`
 	CreatedAt     time.Time  `gorm:"autoCreateTime;index;comment:创建时间" json:"created_at"`
 	UpdatedAt     time.Time  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+	TrashedAt     *time.Time `gorm:"index;comment:移入回收站时间" json:"trashed_at,omitempty"`
+	TrashedFrom   string     `gorm:"size:20;comment:移入回收站前的状态" json:"trashed_from,omitempty"`
+	Version       uint64     `gorm:"not null;default:1;comment:版本号(乐观锁)" json:"version"`
+
+	// SEO 元数据
+	SEOMeta
+
+	// 语言与翻译组
+	TranslationMeta
 
 	// 处理后字段
 	CategoryNames []string `json:"category_names" gorm:"-"`
 	CategoryIDs   []uint64 `json:"category_ids" gorm:"-"`
 	TagNames      []string `json:"tag_names" gorm:"-"`
 	TagIDs        []uint64 `json:"tag_ids" gorm:"-"`
+
+	// 作者公开信息，列表与详情接口填充
+	Author *AuthorSummary `json:"author,omitempty" gorm:"-"`
+
+	// 系列导航，仅详情接口填充
+	Series *SeriesNav `json:"series,omitempty" gorm:"-"`
+
+	// 其他语言版本，仅详情接口填充
+	Translations []TranslationLink `json:"translations,omitempty" gorm:"-"`
 }
 type PostWithRelations struct {
 	Post
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PostAutosave 编辑器自动保存的草稿缓冲 - 每个用户每篇文章一条（新建未保存的文章 PostID 为 0），
+// 与文章正文分开存放，正式保存后删除，过期后自动清理
+type PostAutosave struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	UserID      uint64    `gorm:"not null;uniqueIndex:idx_post_autosave_owner,priority:1;comment:编辑者用户ID" json:"user_id"`
+	PostID      uint64    `gorm:"not null;default:0;uniqueIndex:idx_post_autosave_owner,priority:2;index;comment:文章ID(新建文章为0)" json:"post_id"`
+	Title       string    `gorm:"size:200;comment:标题" json:"title"`
+	Excerpt     string    `gorm:"type:text;comment:摘要" json:"excerpt"`
+	Content     string    `gorm:"type:longtext;comment:内容" json:"content"`
+	CoverImage  string    `gorm:"size:255;comment:封面图片URL" json:"cover_image"`
+	BaseVersion uint64    `gorm:"not null;default:0;comment:开始编辑时的文章版本号" json:"base_version"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime;comment:最近自动保存时间" json:"updated_at"`
+	ExpiresAt   time.Time `gorm:"not null;index;comment:过期时间" json:"expires_at"`
+}
+
+func (PostAutosave) TableName() string { return "post_autosaves" }
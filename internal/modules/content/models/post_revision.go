@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PostRevision 文章修订记录 - 每次后台更新文章前保存一份旧内容快照，用于对比与回滚
+type PostRevision struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement;comment:修订记录ID" json:"id"`
+	PostID     uint64    `gorm:"index:idx_post_revision_post,priority:1;not null;comment:关联文章ID" json:"post_id"`
+	RevisionNo int       `gorm:"index:idx_post_revision_post,priority:2;not null;comment:文章内修订序号" json:"revision_no"`
+	Title      string    `gorm:"size:200;not null;comment:标题快照" json:"title"`
+	Excerpt    string    `gorm:"type:text;comment:摘要快照" json:"excerpt"`
+	Content    string    `gorm:"type:longtext;not null;comment:内容快照" json:"content,omitempty"`
+	EditorID   uint64    `gorm:"index;comment:触发修订的用户ID" json:"editor_id"`
+	Reason     string    `gorm:"size:50;default:'update';comment:修订来源(update/restore)" json:"reason"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+func (PostRevision) TableName() string { return "post_revisions" }
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// PostSlugHistory 文章历史slug - 文章slug变更后记录旧值，访问旧地址时提示跳转到当前slug
+type PostSlugHistory struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement;comment:记录ID" json:"id"`
+	PostID    uint64    `gorm:"index;not null;comment:文章ID" json:"post_id"`
+	Slug      string    `gorm:"size:200;not null;uniqueIndex;comment:历史URL标识" json:"slug"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:变更时间" json:"created_at"`
+}
+
+func (PostSlugHistory) TableName() string { return "post_slug_histories" }
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// PreviewKey 预览链接签名密钥 - 每个内容一条，轮换 Nonce 即可让该内容已发出的预览链接全部失效
+type PreviewKey struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement;comment:主键ID" json:"id"`
+	ContentType string    `gorm:"size:20;not null;uniqueIndex:idx_preview_key_content,priority:1;comment:内容类型(post/page/moment)" json:"content_type"`
+	ContentID   uint64    `gorm:"not null;uniqueIndex:idx_preview_key_content,priority:2;comment:内容ID" json:"content_id"`
+	Nonce       string    `gorm:"size:64;not null;comment:参与签名的随机值" json:"-"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime;comment:最近轮换时间" json:"updated_at"`
+}
+
+func (PreviewKey) TableName() string { return "preview_keys" }
@@ -0,0 +1,11 @@
+package models
+
+// SEOMeta 文章与页面共用的 SEO / Open Graph 字段
+// 留空时详情接口会回退到标题、摘要与封面图
+type SEOMeta struct {
+	MetaTitle       string `gorm:"size:200;comment:SEO标题" json:"meta_title"`
+	MetaDescription string `gorm:"size:500;comment:SEO描述" json:"meta_description"`
+	CanonicalURL    string `gorm:"size:255;comment:规范链接" json:"canonical_url"`
+	OGImage         string `gorm:"column:og_image;size:255;comment:分享图片URL" json:"og_image"`
+	NoIndex         bool   `gorm:"column:noindex;default:false;comment:禁止搜索引擎收录" json:"noindex"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// Series 文章系列 - 多篇文章按顺序组成的合集（如分篇教程）
+type Series struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement;comment:系列唯一ID" json:"id"`
+	Title       string    `gorm:"size:200;not null;comment:系列标题" json:"title"`
+	Slug        string    `gorm:"size:200;not null;uniqueIndex;comment:系列URL标识" json:"slug"`
+	Description string    `gorm:"type:text;comment:系列简介" json:"description"`
+	CoverImage  string    `gorm:"size:255;comment:封面图片URL" json:"cover_image"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+
+	// 处理后字段
+	PostCount int `json:"post_count" gorm:"-"`
+}
+
+func (Series) TableName() string { return "series" }
+
+// SeriesPost 系列与文章的有序关联 - 一篇文章最多属于一个系列
+type SeriesPost struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement;comment:关联记录ID" json:"id"`
+	SeriesID  uint64    `gorm:"index:idx_series_post_position,priority:1;not null;comment:系列ID" json:"series_id"`
+	PostID    uint64    `gorm:"uniqueIndex;not null;comment:文章ID" json:"post_id"`
+	Position  int       `gorm:"index:idx_series_post_position,priority:2;not null;comment:系列内顺序(从1开始)" json:"position"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+func (SeriesPost) TableName() string { return "series_posts" }
+
+// SeriesPart 系列中的一篇文章（列表展示用）
+type SeriesPart struct {
+	PostID      uint64     `json:"post_id"`
+	Position    int        `json:"position"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Excerpt     string     `json:"excerpt,omitempty"`
+	CoverImage  string     `json:"cover_image,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Visibility  string     `json:"visibility,omitempty"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// SeriesNav 文章详情中的系列导航
+type SeriesNav struct {
+	ID       uint64      `json:"id"`
+	Title    string      `json:"title"`
+	Slug     string      `json:"slug"`
+	Position int         `json:"position"` // 当前文章在系列中的序号（从1开始）
+	Total    int         `json:"total"`
+	Prev     *SeriesPart `json:"prev"`
+	Next     *SeriesPart `json:"next"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// TranslationMeta 文章与页面共用的多语言字段
+// 同一内容的不同语言版本共享 TranslationGroup，为空表示没有其他语言版本
+type TranslationMeta struct {
+	Lang             string `gorm:"size:16;not null;default:'zh-CN';index;comment:语言代码(BCP 47)" json:"lang"`
+	TranslationGroup string `gorm:"size:36;index;comment:翻译组标识" json:"translation_group"`
+}
+
+// TranslationLink 详情接口中列出的其他语言版本
+type TranslationLink struct {
+	ID    uint64 `json:"id"`
+	Lang  string `json:"lang"`
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
@@ -25,3 +25,11 @@ type User struct {
 }
 
 func (User) TableName() string { return "users" }
+
+// AuthorSummary 文章列表与详情中展示的作者信息，只包含公开字段
+type AuthorSummary struct {
+	ID          uint64 `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+}
@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"api/internal/middleware"
+	adminCtrl "api/internal/modules/content/controllers/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminExportRoutes(r *gin.Engine) {
+	adminGroup := r.Group("/api/admin")
+	adminGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		adminGroup.GET("/export", adminCtrl.ExportContent) // 导出全部内容为 Markdown zip
+	}
+}
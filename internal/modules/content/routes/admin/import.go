@@ -0,0 +1,19 @@
+package admin
+
+import (
+	"api/internal/middleware"
+	adminCtrl "api/internal/modules/content/controllers/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminImportRoutes(r *gin.Engine) {
+	adminGroup := r.Group("/api/admin")
+	adminGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		imports := adminGroup.Group("/import")
+		{
+			imports.POST("/wxr", adminCtrl.ImportWXR) // 上传 WordPress WXR 文件导入
+		}
+	}
+}
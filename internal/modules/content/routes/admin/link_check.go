@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"api/internal/middleware"
+	adminCtrl "api/internal/modules/content/controllers/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminLinkCheckRoutes(r *gin.Engine) {
+	adminGroup := r.Group("/api/admin")
+	adminGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		adminGroup.GET("/links/check", adminCtrl.CheckContentLinks) // 失效链接与上传文件引用检查
+	}
+}
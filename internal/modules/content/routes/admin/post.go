@@ -9,16 +9,31 @@ import (
 
 func RegisterAdminPostRoutes(r *gin.Engine) {
 	adminGroup := r.Group("/api/admin")
-	adminGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware()) // 需要管理员权限
+	adminGroup.Use(middleware.AuthMiddleware(), middleware.AuthorOrAdminMiddleware()) // 作者只能管理自己的文章
 	{
 		posts := adminGroup.Group("/posts")
 		{
 			posts.GET("", adminCtrl.ListPosts)                             // 文章列表（支持分页和筛选）
 			posts.POST("/suggest-taxonomy", adminCtrl.SuggestPostTaxonomy) // 根据内容推荐分类和标签
 			posts.POST("", adminCtrl.CreatePost)                           // 创建文章
+			posts.POST("/batch", adminCtrl.BatchPosts)                     // 批量修改状态、分类标签、作者或移入回收站
 			posts.GET("/:id", adminCtrl.GetPost)                           // 获取文章详情
 			posts.PUT("/:id", adminCtrl.UpdatePost)                        // 更新文章
 			posts.DELETE("/:id", adminCtrl.DeletePost)                     // 删除文章
+
+			// 修订历史
+			posts.GET("/:id/revisions", adminCtrl.ListPostRevisions)                        // 修订列表
+			posts.GET("/:id/revisions/diff", adminCtrl.DiffPostRevisions)                   // 修订行级对比
+			posts.GET("/:id/revisions/:revisionId", adminCtrl.GetPostRevision)              // 修订详情
+			posts.POST("/:id/revisions/:revisionId/restore", adminCtrl.RestorePostRevision) // 恢复到指定修订
+
+			// 编辑器自动保存（按用户隔离，不带 :id 的路由用于尚未保存的新文章）
+			posts.GET("/autosave", adminCtrl.GetPostAutosave)
+			posts.PUT("/autosave", adminCtrl.SavePostAutosave)
+			posts.DELETE("/autosave", adminCtrl.DiscardPostAutosave)
+			posts.GET("/:id/autosave", adminCtrl.GetPostAutosave)
+			posts.PUT("/:id/autosave", adminCtrl.SavePostAutosave)
+			posts.DELETE("/:id/autosave", adminCtrl.DiscardPostAutosave)
 		}
 	}
 }
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"api/internal/middleware"
+	adminCtrl "api/internal/modules/content/controllers/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminPreviewRoutes(r *gin.Engine) {
+	adminGroup := r.Group("/api/admin")
+	adminGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		previews := adminGroup.Group("/previews")
+		{
+			previews.POST("/:type/:id", adminCtrl.CreatePreviewLink)    // 生成签名预览链接（type: post/page/moment）
+			previews.DELETE("/:type/:id", adminCtrl.RevokePreviewLinks) // 撤销该内容的全部预览链接
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"api/internal/middleware"
+	adminCtrl "api/internal/modules/content/controllers/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminSeriesRoutes(r *gin.Engine) {
+	adminGroup := r.Group("/api/admin")
+	adminGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		series := adminGroup.Group("/series")
+		{
+			series.GET("", adminCtrl.ListSeries)               // 系列列表
+			series.POST("", adminCtrl.CreateSeries)            // 创建系列
+			series.GET("/:id", adminCtrl.GetSeries)            // 系列详情（含全部文章）
+			series.PUT("/:id", adminCtrl.UpdateSeries)         // 更新系列信息
+			series.PUT("/:id/posts", adminCtrl.SetSeriesPosts) // 按顺序重设系列文章
+			series.DELETE("/:id", adminCtrl.DeleteSeries)      // 删除系列
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package admin
+
+import (
+	"api/internal/middleware"
+	adminCtrl "api/internal/modules/content/controllers/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminTrashRoutes(r *gin.Engine) {
+	adminGroup := r.Group("/api/admin")
+	adminGroup.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		trash := adminGroup.Group("/trash")
+		{
+			trash.GET("", adminCtrl.ListTrash)                   // 回收站列表（文章或评论）
+			trash.POST("/restore", adminCtrl.RestoreTrash)       // 恢复为移入前的状态
+			trash.DELETE("/:type/:id", adminCtrl.PurgeTrashItem) // 彻底删除单条
+			trash.DELETE("", adminCtrl.EmptyTrash)               // 清空回收站
+		}
+	}
+}
@@ -22,6 +22,9 @@ func RegisterAdminUploadRoutes(r *gin.Engine) {
 			upload.GET("/compress/stats", adminCtrl.GetCompressStats)
 			upload.DELETE("/file", adminCtrl.DeleteFile) // 删除文件
 			upload.GET("/files", adminCtrl.ListFiles)    // 获取文件列表
+			// 孤立文件与引用查询
+			upload.GET("/orphans", adminCtrl.ListOrphanUploads)
+			upload.GET("/references", adminCtrl.GetUploadReferences)
 		}
 	}
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"api/internal/middleware"
+	"api/internal/modules/content/controllers"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterArchiveRoutes(r *gin.Engine) {
+	archives := r.Group("/api/archives")
+	{
+		archives.GET("", middleware.RateLimitMiddleware(120, time.Minute), controllers.ListArchives)
+		archives.GET(":year/:month", middleware.RateLimitMiddleware(120, time.Minute), controllers.GetArchiveMonth)
+	}
+}
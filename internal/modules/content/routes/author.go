@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"api/internal/middleware"
+	"api/internal/modules/content/controllers"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAuthorRoutes(r *gin.Engine) {
+	author := r.Group("/api/authors")
+	{
+		author.GET(":username", middleware.RateLimitMiddleware(120, time.Minute), controllers.GetAuthorProfile)
+	}
+}
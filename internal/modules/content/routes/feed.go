@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"api/internal/modules/content/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterFeedRoutes(r *gin.Engine) {
+	feeds := r.Group("/api/feeds")
+	{
+		feeds.GET("/:format", controllers.GetSiteFeed)                    // 全站订阅（rss/atom/json）
+		feeds.GET("/category/:slug/:format", controllers.GetCategoryFeed) // 分类订阅
+		feeds.GET("/tag/:slug/:format", controllers.GetTagFeed)           // 标签订阅
+	}
+}
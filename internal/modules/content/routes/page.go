@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"api/internal/middleware"
 	"api/internal/modules/content/controllers"
 
 	"github.com/gin-gonic/gin"
@@ -10,7 +11,7 @@ func RegisterPageRoutes(r *gin.Engine) {
 	pg := r.Group("/api/pages")
 	{
 		pg.POST("", controllers.CreatePage)
-		pg.GET(":id", controllers.GetPage)
+		pg.GET(":id", middleware.OptionalAuthMiddleware(), controllers.GetPage)
 		pg.GET("", controllers.ListPages)
 		pg.PUT(":id", controllers.UpdatePage)
 		pg.DELETE(":id", controllers.DeletePage)
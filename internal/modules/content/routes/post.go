@@ -11,10 +11,14 @@ import (
 func RegisterPostRoutes(r *gin.Engine) {
 	post := r.Group("/api/posts")
 	{
-		post.POST("", controllers.CreatePost)
-		post.GET(":id", middleware.RateLimitMiddleware(300, time.Minute), controllers.GetPost)
+		// 写接口需要作者或管理员登录，作者只能修改自己的文章
+		post.POST("", middleware.AuthMiddleware(), middleware.AuthorOrAdminMiddleware(), controllers.CreatePost)
+		post.GET(":id", middleware.RateLimitMiddleware(300, time.Minute), middleware.OptionalAuthMiddleware(), controllers.GetPost)
+		post.GET("slug/:slug", middleware.RateLimitMiddleware(300, time.Minute), middleware.OptionalAuthMiddleware(), controllers.GetPostBySlug)
+		post.POST(":id/unlock", middleware.RateLimitMiddleware(20, time.Minute), controllers.UnlockPost)
+		post.GET(":id/related", middleware.RateLimitMiddleware(180, time.Minute), controllers.GetRelatedPosts)
 		post.GET("", middleware.RateLimitMiddleware(180, time.Minute), controllers.ListPosts)
-		post.PUT(":id", controllers.UpdatePost)
-		post.DELETE(":id", controllers.DeletePost)
+		post.PUT(":id", middleware.AuthMiddleware(), middleware.AuthorOrAdminMiddleware(), controllers.UpdatePost)
+		post.DELETE(":id", middleware.AuthMiddleware(), middleware.AuthorOrAdminMiddleware(), controllers.DeletePost)
 	}
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"api/internal/middleware"
+	"api/internal/modules/content/controllers"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPreviewRoutes(r *gin.Engine) {
+	preview := r.Group("/api/preview")
+	{
+		preview.GET("/:type/:id", middleware.RateLimitMiddleware(120, time.Minute), controllers.GetPreview)
+	}
+}
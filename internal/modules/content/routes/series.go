@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"api/internal/middleware"
+	"api/internal/modules/content/controllers"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSeriesRoutes(r *gin.Engine) {
+	series := r.Group("/api/series")
+	{
+		series.GET("", middleware.RateLimitMiddleware(120, time.Minute), controllers.ListSeries)
+		series.GET(":slug", middleware.RateLimitMiddleware(120, time.Minute), controllers.GetSeries)
+	}
+}
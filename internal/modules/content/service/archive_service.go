@@ -0,0 +1,142 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/platform/redisstore"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	archiveCacheTTL       = 24 * time.Hour
+	archiveGenerationKey  = "posts:archives:generation"
+	archiveCacheKeyPrefix = "posts:archives:"
+)
+
+var ErrInvalidArchiveMonth = errors.New("无效的年份或月份")
+
+// 未启用 Redis 时使用进程内缓存，失效时整体清空；
+// 版本号用于丢弃失效前开始、失效后才写回的查询结果
+var (
+	localArchiveMu         sync.Mutex
+	localArchiveGeneration int64
+	localArchiveCache      = make(map[string][]byte)
+)
+
+// ListArchiveMonths 按年月统计已发布文章数（私密文章不计入）
+func ListArchiveMonths() ([]dao.ArchiveMonth, error) {
+	var months []dao.ArchiveMonth
+	err := cachedArchive("index", &months, func() (any, error) {
+		rows, err := dao.ListArchiveMonths()
+		if rows == nil {
+			rows = []dao.ArchiveMonth{}
+		}
+		return rows, err
+	})
+	return months, err
+}
+
+// ListArchivePosts 某年某月已发布文章的标题、slug 与发布时间
+func ListArchivePosts(year, month int) ([]dao.ArchivePost, error) {
+	if year < 1 || year > 9999 || month < 1 || month > 12 {
+		return nil, ErrInvalidArchiveMonth
+	}
+	var posts []dao.ArchivePost
+	key := fmt.Sprintf("%04d-%02d", year, month)
+	err := cachedArchive(key, &posts, func() (any, error) {
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+		rows, err := dao.ListArchivePosts(start, start.AddDate(0, 1, 0))
+		if rows == nil {
+			rows = []dao.ArchivePost{}
+		}
+		return rows, err
+	})
+	return posts, err
+}
+
+// InvalidateArchives 使归档缓存失效（文章发布状态、发布时间或可见性变化时调用）
+func InvalidateArchives() {
+	localArchiveMu.Lock()
+	localArchiveGeneration++
+	localArchiveCache = make(map[string][]byte)
+	localArchiveMu.Unlock()
+
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Incr(ctx, archiveGenerationKey).Err(); err != nil {
+		fmt.Printf("[archives] redis incr generation error: %v\n", err)
+	}
+}
+
+// cachedArchive 读取缓存到 dest，未命中时调用 load 查询并写回缓存。
+// Redis 中的缓存键带版本号，InvalidateArchives 递增版本即可让所有实例同时失效
+func cachedArchive(name string, dest any, load func() (any, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	client, err := redisstore.GetClient()
+	if err != nil {
+		client = nil
+	}
+
+	var cacheKey string
+	var localGeneration int64
+	if client != nil {
+		generation, err := client.Get(ctx, archiveGenerationKey).Int64()
+		if err != nil && err != redis.Nil {
+			fmt.Printf("[archives] redis get generation error: %v\n", err)
+			client = nil
+		}
+		cacheKey = archiveCacheKeyPrefix + strconv.FormatInt(generation, 10) + ":" + name
+	}
+
+	if client != nil {
+		value, err := client.Get(ctx, cacheKey).Bytes()
+		if err == nil && json.Unmarshal(value, dest) == nil {
+			return nil
+		}
+		if err != nil && err != redis.Nil {
+			fmt.Printf("[archives] redis get error: %v\n", err)
+		}
+	} else {
+		localArchiveMu.Lock()
+		value, ok := localArchiveCache[name]
+		localGeneration = localArchiveGeneration
+		localArchiveMu.Unlock()
+		if ok && json.Unmarshal(value, dest) == nil {
+			return nil
+		}
+	}
+
+	result, err := load()
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if client != nil {
+		if err := client.Set(ctx, cacheKey, payload, archiveCacheTTL).Err(); err != nil {
+			fmt.Printf("[archives] redis set error: %v\n", err)
+		}
+	} else {
+		localArchiveMu.Lock()
+		if localGeneration == localArchiveGeneration {
+			localArchiveCache[name] = payload
+		}
+		localArchiveMu.Unlock()
+	}
+	return json.Unmarshal(payload, dest)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"errors"
+	"time"
+)
+
+var ErrAuthorNotFound = errors.New("作者不存在")
+
+// AuthorProfile 作者公开主页信息，不包含邮箱、角色等账号字段
+type AuthorProfile struct {
+	ID              uint64     `json:"id"`
+	Username        string     `json:"username"`
+	DisplayName     string     `json:"display_name"`
+	AvatarURL       string     `json:"avatar_url"`
+	Bio             string     `json:"bio"`
+	Website         string     `json:"website"`
+	Location        string     `json:"location"`
+	PostCount       int64      `json:"post_count"`
+	ViewCount       int64      `json:"view_count"`
+	LastPublishedAt *time.Time `json:"last_published_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// GetAuthorProfile 按用户名获取作者主页；订阅者与非正常状态的账号视为不存在
+func GetAuthorProfile(username string) (*AuthorProfile, error) {
+	user, err := dao.GetUserByUsername(username)
+	if err != nil {
+		return nil, ErrAuthorNotFound
+	}
+	if user.Status != "active" || (user.Role != "admin" && user.Role != "author") {
+		return nil, ErrAuthorNotFound
+	}
+
+	stats, err := dao.GetAuthorPostStats(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorProfile{
+		ID:              user.ID,
+		Username:        user.Username,
+		DisplayName:     user.DisplayName,
+		AvatarURL:       user.AvatarURL,
+		Bio:             user.Bio,
+		Website:         user.Website,
+		Location:        user.Location,
+		PostCount:       stats.PostCount,
+		ViewCount:       stats.ViewCount,
+		LastPublishedAt: stats.LastPublishedAt,
+		CreatedAt:       user.CreatedAt,
+	}, nil
+}
+
+// CanEditPost 管理员可以编辑所有文章，作者只能编辑自己的文章
+func CanEditPost(post *models.Post, userID uint64, role string) bool {
+	switch role {
+	case "admin":
+		return true
+	case "author":
+		return userID != 0 && post.AuthorID == userID
+	default:
+		return false
+	}
+}
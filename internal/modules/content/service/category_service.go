@@ -6,7 +6,11 @@ import (
 )
 
 func CreateCategory(c *models.Category) error {
-	return dao.CreateCategory(c)
+	if err := dao.CreateCategory(c); err != nil {
+		return err
+	}
+	notifyPostsChanged()
+	return nil
 }
 func GetCategoryByID(id uint64) (*models.Category, error) {
 	return dao.GetCategoryByID(id)
@@ -16,12 +20,23 @@ func GetCategoryByID(id uint64) (*models.Category, error) {
 func GetCategoryByIDFull(id uint64) (*models.Category, error) {
 	return dao.GetCategoryByIDFull(id)
 }
+func GetCategoryBySlug(slug string) (*models.Category, error) {
+	return dao.GetCategoryBySlug(slug)
+}
 func ListCategories() ([]models.Category, error) {
 	return dao.ListCategories()
 }
 func UpdateCategory(c *models.Category) error {
-	return dao.UpdateCategory(c)
+	if err := dao.UpdateCategory(c); err != nil {
+		return err
+	}
+	notifyPostsChanged()
+	return nil
 }
 func DeleteCategory(id uint64) error {
-	return dao.DeleteCategory(id)
+	if err := dao.DeleteCategory(id); err != nil {
+		return err
+	}
+	notifyPostsChanged()
+	return nil
 }
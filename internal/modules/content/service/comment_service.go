@@ -22,8 +22,10 @@ func UpdateComment(c *models.Comment) error {
 	return dao.UpdateComment(c)
 }
 
+// 删除评论只移入回收站
 func DeleteComment(id uint64) error {
-	return dao.DeleteComment(id)
+	_, err := TrashComments([]uint64{id})
+	return err
 }
 
 // 分页响应结构
@@ -75,13 +77,18 @@ func ListCommentsWithPagination(page, pageSize int, postID uint64, status, q, so
 	}, nil
 }
 
-// 批量删除评论
+// 批量删除评论（移入回收站）
 func DeleteComments(ids []uint64) error {
-	return dao.DeleteComments(ids)
+	_, err := TrashComments(ids)
+	return err
 }
 
-// 批量更新评论状态
+// 批量更新评论状态；改为 trash 时记录原状态，便于从回收站恢复
 func UpdateCommentsStatus(ids []uint64, status string) error {
+	if status == StatusTrash {
+		_, err := TrashComments(ids)
+		return err
+	}
 	return dao.UpdateCommentsStatus(ids, status)
 }
 
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"fmt"
+)
+
+// ErrVersionConflict 保存时文章、页面或动态已被其他请求修改
+var ErrVersionConflict = dao.ErrVersionConflict
+
+// notifyPostsChanged 文章内容、状态、可见性或分类标签变化后调用，统一失效依赖文章数据的派生缓存；
+// postIDs 为发生变化的文章，相关文章只重新计算受影响的部分，为空表示分类、标签等影响全部文章的变化
+func notifyPostsChanged(postIDs ...uint64) {
+	if len(postIDs) > 0 {
+		MarkRelatedPostsDirty(postIDs)
+	} else {
+		InvalidateRelatedPosts()
+	}
+	InvalidateFeeds()
+	InvalidateSitemap()
+	InvalidateArchives()
+}
+
+// recountPostTaxonomies 文章发布状态或分类标签变化后，只重算受影响分类、标签的已发布文章数；
+// categoryIDs、tagIDs 为变更前的关联，与文章当前的关联取并集
+func recountPostTaxonomies(postIDs, categoryIDs, tagIDs []uint64) {
+	if err := dao.RecountPostTaxonomies(postIDs, categoryIDs, tagIDs); err != nil {
+		fmt.Printf("[content] recount taxonomy posts error: %v\n", err)
+	}
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"api/internal/modules/media"
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 导出包格式版本，写入 manifest.json
+const contentExportVersion = 1
+
+// ContentExportReport 导出结果
+type ContentExportReport struct {
+	Path         string   `json:"path"`
+	Posts        int      `json:"posts"`
+	Pages        int      `json:"pages"`
+	Moments      int      `json:"moments"`
+	Files        int      `json:"files"`
+	MissingFiles []string `json:"missing_files,omitempty"`
+}
+
+// exportFrontMatter 导出文件的 front matter，字段名与 ImportMarkdownDir 识别的键一致
+type exportFrontMatter struct {
+	Type            string   `yaml:"type"`
+	Title           string   `yaml:"title,omitempty"`
+	Slug            string   `yaml:"slug,omitempty"`
+	Date            string   `yaml:"date"`
+	Updated         string   `yaml:"updated,omitempty"`
+	Status          string   `yaml:"status"`
+	Draft           bool     `yaml:"draft"`
+	Visibility      string   `yaml:"visibility,omitempty"`
+	CommentStatus   string   `yaml:"comment_status,omitempty"`
+	Excerpt         string   `yaml:"excerpt,omitempty"`
+	Cover           string   `yaml:"cover,omitempty"`
+	Categories      []string `yaml:"categories,omitempty"`
+	Tags            []string `yaml:"tags,omitempty"`
+	Template        string   `yaml:"template,omitempty"`
+	MenuOrder       int      `yaml:"menu_order,omitempty"`
+	Mood            string   `yaml:"mood,omitempty"`
+	Images          []string `yaml:"images,omitempty"`
+	MetaTitle       string   `yaml:"meta_title,omitempty"`
+	MetaDescription string   `yaml:"meta_description,omitempty"`
+	CanonicalURL    string   `yaml:"canonical_url,omitempty"`
+	OGImage         string   `yaml:"og_image,omitempty"`
+	NoIndex         bool     `yaml:"noindex,omitempty"`
+	Lang            string   `yaml:"lang,omitempty"`
+	TranslationKey  string   `yaml:"translation_key,omitempty"`
+}
+
+type exportTaxonomy struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+}
+
+// contentExportManifest 导出包根目录的 manifest.json，导入时用于还原分类、标签的 slug 与描述
+type contentExportManifest struct {
+	Version    int              `json:"version"`
+	ExportedAt time.Time        `json:"exported_at"`
+	SiteTitle  string           `json:"site_title"`
+	BaseURL    string           `json:"base_url"`
+	Posts      int              `json:"posts"`
+	Pages      int              `json:"pages"`
+	Moments    int              `json:"moments"`
+	Files      int              `json:"files"`
+	Categories []exportTaxonomy `json:"categories"`
+	Tags       []exportTaxonomy `json:"tags"`
+}
+
+// ExportContent 将全部文章、页面、动态导出为带 front matter 的 Markdown，
+// 连同引用到的上传文件打包为 zip。target 为空时写入 media.CompressedTempDir（1 小时后自动清理）。
+// 回收站中的文章不导出；密码保护的文章以 private 导出，不包含密码。
+func ExportContent(target string) (*ContentExportReport, error) {
+	posts, err := dao.ListPosts()
+	if err != nil {
+		return nil, err
+	}
+	pages, err := dao.ListPages()
+	if err != nil {
+		return nil, err
+	}
+	moments, err := dao.ListMoments("", 0)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := dao.ListCategories()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := dao.ListTags()
+	if err != nil {
+		return nil, err
+	}
+	postCategoryIDs, err := dao.ListAllPostCategoryIDs()
+	if err != nil {
+		return nil, err
+	}
+	postTagIDs, err := dao.ListAllPostTagIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	if target == "" {
+		if err := os.MkdirAll(media.CompressedTempDir, 0o755); err != nil {
+			return nil, fmt.Errorf("创建临时目录失败: %w", err)
+		}
+		target = filepath.Join(media.CompressedTempDir, fmt.Sprintf("export-%s.zip", time.Now().Format("20060102-150405")))
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return nil, fmt.Errorf("创建 zip 文件失败: %w", err)
+	}
+	defer f.Close()
+
+	w := &contentExportWriter{
+		zw:      zip.NewWriter(f),
+		now:     time.Now(),
+		uploads: newUploadRefCollector(),
+	}
+	defer w.zw.Close()
+
+	categoryNames := make(map[uint64]string, len(categories))
+	manifest := contentExportManifest{
+		Version:    contentExportVersion,
+		ExportedAt: w.now,
+		SiteTitle:  config.Load().SiteTitle,
+		BaseURL:    config.GetBaseURL(),
+		Categories: make([]exportTaxonomy, 0, len(categories)),
+		Tags:       make([]exportTaxonomy, 0, len(tags)),
+	}
+	for _, c := range categories {
+		categoryNames[c.ID] = c.Name
+		manifest.Categories = append(manifest.Categories, exportTaxonomy{Name: c.Name, Slug: c.Slug, Description: c.Description})
+	}
+	tagNames := make(map[uint64]string, len(tags))
+	for _, t := range tags {
+		tagNames[t.ID] = t.Name
+		manifest.Tags = append(manifest.Tags, exportTaxonomy{Name: t.Name, Slug: t.Slug, Description: t.Description})
+	}
+
+	report := &ContentExportReport{Path: target}
+	for i := range posts {
+		post := &posts[i]
+		if post.Status == "trash" {
+			continue
+		}
+		if err := w.writeDocument("posts/"+post.Slug+".md", postFrontMatter(post, lookupNames(postCategoryIDs[post.ID], categoryNames), lookupNames(postTagIDs[post.ID], tagNames)), post.Content); err != nil {
+			return nil, err
+		}
+		report.Posts++
+	}
+	for i := range pages {
+		if err := w.writeDocument("pages/"+pages[i].Slug+".md", pageFrontMatter(&pages[i]), pages[i].Content); err != nil {
+			return nil, err
+		}
+		report.Pages++
+	}
+	for i := range moments {
+		moment := &moments[i]
+		name := fmt.Sprintf("moments/%s-%d.md", moment.CreatedAt.Format("20060102-150405"), moment.ID)
+		if err := w.writeDocument(name, w.momentFrontMatter(moment), moment.Content); err != nil {
+			return nil, err
+		}
+		report.Moments++
+	}
+
+	// 引用到的上传文件按原路径放在 uploads/ 下
+	for _, ref := range w.uploads.sorted() {
+		ok, err := w.writeUpload(ref)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			report.MissingFiles = append(report.MissingFiles, ref)
+			continue
+		}
+		report.Files++
+	}
+
+	manifest.Posts, manifest.Pages, manifest.Moments, manifest.Files = report.Posts, report.Pages, report.Moments, report.Files
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := w.writeEntry("manifest.json", data); err != nil {
+		return nil, err
+	}
+
+	if err := w.zw.Close(); err != nil {
+		return nil, fmt.Errorf("关闭 zip 写入器失败: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("关闭 zip 文件失败: %w", err)
+	}
+	return report, nil
+}
+
+func postFrontMatter(post *models.Post, categories, tags []string) *exportFrontMatter {
+	fm := &exportFrontMatter{
+		Type:          PreviewTypePost,
+		Title:         post.Title,
+		Slug:          post.Slug,
+		Date:          exportTime(post.CreatedAt),
+		Updated:       exportTime(post.UpdatedAt),
+		Status:        post.Status,
+		Draft:         post.Status == "draft" || post.Status == "pending",
+		Visibility:    post.Visibility,
+		CommentStatus: post.CommentStatus,
+		Excerpt:       post.Excerpt,
+		Cover:         post.CoverImage,
+		Categories:    categories,
+		Tags:          tags,
+	}
+	if post.PublishedAt != nil {
+		fm.Date = exportTime(*post.PublishedAt)
+	}
+	if fm.Visibility == VisibilityPassword {
+		fm.Visibility = VisibilityPrivate
+	}
+	applyExportSEO(fm, post.SEOMeta)
+	applyExportTranslation(fm, post.TranslationMeta)
+	return fm
+}
+
+func pageFrontMatter(page *models.Page) *exportFrontMatter {
+	fm := &exportFrontMatter{
+		Type:      PreviewTypePage,
+		Title:     page.Title,
+		Slug:      page.Slug,
+		Date:      exportTime(page.CreatedAt),
+		Updated:   exportTime(page.UpdatedAt),
+		Status:    page.Status,
+		Draft:     page.Status != "published",
+		Excerpt:   page.Excerpt,
+		Template:  page.Template,
+		MenuOrder: page.MenuOrder,
+	}
+	applyExportSEO(fm, page.SEOMeta)
+	applyExportTranslation(fm, page.TranslationMeta)
+	return fm
+}
+
+func (w *contentExportWriter) momentFrontMatter(moment *models.Moment) *exportFrontMatter {
+	fm := &exportFrontMatter{
+		Type:    PreviewTypeMoment,
+		Date:    exportTime(moment.CreatedAt),
+		Updated: exportTime(moment.UpdatedAt),
+		Status:  moment.Status,
+		Draft:   moment.Status == "draft",
+		Mood:    moment.Mood,
+	}
+	if moment.PublishedAt != nil {
+		fm.Date = exportTime(*moment.PublishedAt)
+	}
+	for _, image := range moment.Images {
+		fm.Images = append(fm.Images, w.uploads.relativize(image))
+	}
+	return fm
+}
+
+func applyExportSEO(fm *exportFrontMatter, meta models.SEOMeta) {
+	fm.MetaTitle = meta.MetaTitle
+	fm.MetaDescription = meta.MetaDescription
+	fm.CanonicalURL = meta.CanonicalURL
+	fm.OGImage = meta.OGImage
+	fm.NoIndex = meta.NoIndex
+}
+
+// applyExportTranslation 翻译组导出为 translation_key，重新导入时据此恢复各语言版本之间的关联
+func applyExportTranslation(fm *exportFrontMatter, meta models.TranslationMeta) {
+	fm.Lang = meta.Lang
+	fm.TranslationKey = meta.TranslationGroup
+}
+
+func exportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func lookupNames(ids []uint64, names map[uint64]string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name, ok := names[id]; ok {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+type contentExportWriter struct {
+	zw      *zip.Writer
+	now     time.Time
+	uploads *uploadRefCollector
+}
+
+// writeDocument 写入一个 Markdown 文件；本站上传文件的完整地址改写为 /uploads/ 开头的站内路径
+func (w *contentExportWriter) writeDocument(name string, fm *exportFrontMatter, body string) error {
+	fm.Cover = w.uploads.relativize(fm.Cover)
+	fm.OGImage = w.uploads.relativize(fm.OGImage)
+	header, err := yaml.Marshal(fm)
+	if err != nil {
+		return fmt.Errorf("生成 %s 的 front matter 失败: %w", name, err)
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.Write(header)
+	b.WriteString("---\n\n")
+	b.WriteString(strings.TrimSpace(w.uploads.relativize(body)))
+	b.WriteString("\n")
+	return w.writeEntry(name, []byte(b.String()))
+}
+
+func (w *contentExportWriter) writeEntry(name string, data []byte) error {
+	writer, err := w.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: w.now,
+	})
+	if err != nil {
+		return fmt.Errorf("写入 zip 头失败: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return fmt.Errorf("写入 zip 内容失败: %w", err)
+	}
+	return nil
+}
+
+// writeUpload 复制 /uploads/ 下的文件到 zip；文件不存在时返回 false
+func (w *contentExportWriter) writeUpload(ref string) (bool, error) {
+	src := filepath.Join(media.UploadDir, filepath.FromSlash(strings.TrimPrefix(ref, "/uploads/")))
+	in, err := os.Open(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil || info.IsDir() {
+		return false, err
+	}
+
+	writer, err := w.zw.CreateHeader(&zip.FileHeader{
+		Name:     strings.TrimPrefix(ref, "/"),
+		Method:   zip.Deflate,
+		Modified: info.ModTime(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("写入 zip 头失败: %w", err)
+	}
+	if _, err := io.Copy(writer, in); err != nil {
+		return false, fmt.Errorf("写入 zip 内容失败: %w", err)
+	}
+	return true, nil
+}
+
+// uploadRefCollector 改写并记录内容中引用的本站上传文件
+type uploadRefCollector struct {
+	pattern *regexp.Regexp
+	refs    map[string]bool
+}
+
+func newUploadRefCollector() *uploadRefCollector {
+	// 仅匹配本站地址或以 /uploads/ 开头的站内路径，第三方域名下的 /uploads/ 不受影响
+	base := regexp.QuoteMeta(strings.TrimRight(config.GetBaseURL(), "/"))
+	return &uploadRefCollector{
+		pattern: regexp.MustCompile(`(^|[\s"'(<=\[,])(?:` + base + `)?(/uploads/[^\s"'()<>?#\]]+)`),
+		refs:    make(map[string]bool),
+	}
+}
+
+func (u *uploadRefCollector) relativize(text string) string {
+	if text == "" {
+		return text
+	}
+	return u.pattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := u.pattern.FindStringSubmatch(match)
+		ref := path.Clean(parts[2])
+		// 不导出越界路径与临时压缩包
+		if strings.HasPrefix(ref, "/uploads/") && !strings.HasPrefix(ref, "/uploads/compressed_tmp/") {
+			u.refs[ref] = true
+		}
+		return parts[1] + parts[2]
+	})
+}
+
+func (u *uploadRefCollector) sorted() []string {
+	refs := make([]string, 0, len(u.refs))
+	for ref := range u.refs {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PreviewTypePost   = "post"
+	PreviewTypePage   = "page"
+	PreviewTypeMoment = "moment"
+
+	defaultPreviewTTL = 72 * time.Hour
+	maxPreviewTTL     = 30 * 24 * time.Hour
+)
+
+// 可生成预览链接的状态（未发布）
+var previewableStatuses = map[string]bool{"draft": true, "pending": true, StatusScheduled: true}
+
+var (
+	ErrPreviewUnsupported = errors.New("type 只能是 post、page 或 moment")
+	ErrPreviewPublished   = errors.New("只能为草稿、待审核或定时发布的内容生成预览链接")
+	ErrPreviewInvalid     = errors.New("预览链接无效或已过期")
+)
+
+// PreviewLink 预览链接
+type PreviewLink struct {
+	Type      string    `json:"type"`
+	ID        uint64    `json:"id"`
+	URL       string    `json:"url"`
+	Expires   int64     `json:"expires"`
+	Signature string    `json:"sig"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreatePreviewLink 为未发布的内容生成带过期时间的签名预览链接；ttl<=0 时使用 PREVIEW_TTL
+func CreatePreviewLink(contentType string, id uint64, ttl time.Duration) (*PreviewLink, error) {
+	status, err := previewContentStatus(contentType, id)
+	if err != nil {
+		return nil, err
+	}
+	if !previewableStatuses[status] {
+		return nil, ErrPreviewPublished
+	}
+
+	if ttl <= 0 {
+		ttl = config.Load().PreviewTTL
+	}
+	if ttl <= 0 {
+		ttl = defaultPreviewTTL
+	}
+	if ttl > maxPreviewTTL {
+		ttl = maxPreviewTTL
+	}
+
+	nonce, err := dao.GetOrCreatePreviewNonce(contentType, id, newPreviewNonce())
+	if err != nil {
+		return nil, err
+	}
+
+	return newPreviewLink(contentType, id, time.Now().Add(ttl).Truncate(time.Second), nonce), nil
+}
+
+// newPreviewLink 用内容当前的签名密钥生成预览链接
+func newPreviewLink(contentType string, id uint64, expiresAt time.Time, nonce string) *PreviewLink {
+	sig := signPreview(contentType, id, expiresAt.Unix(), nonce)
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("sig", sig)
+
+	return &PreviewLink{
+		Type:      contentType,
+		ID:        id,
+		URL:       fmt.Sprintf("%s/api/preview/%s/%d?%s", strings.TrimRight(config.GetBaseURL(), "/"), contentType, id, query.Encode()),
+		Expires:   expiresAt.Unix(),
+		Signature: sig,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// RevokePreviewLinks 轮换签名密钥，使该内容已发出的预览链接全部失效
+func RevokePreviewLinks(contentType string, id uint64) error {
+	if _, err := previewContentStatus(contentType, id); err != nil {
+		return err
+	}
+	return dao.RotatePreviewNonce(contentType, id, newPreviewNonce())
+}
+
+// VerifyPreviewLink 校验预览链接签名与过期时间
+func VerifyPreviewLink(contentType string, id uint64, expires int64, sig string) error {
+	if !isPreviewType(contentType) {
+		return ErrPreviewUnsupported
+	}
+	if sig == "" || time.Now().Unix() > expires {
+		return ErrPreviewInvalid
+	}
+	nonce, err := dao.GetPreviewNonce(contentType, id)
+	if err != nil {
+		return err
+	}
+	return checkPreviewSignature(contentType, id, expires, sig, nonce, time.Now())
+}
+
+// checkPreviewSignature 用内容当前的签名密钥校验预览链接；密钥被轮换（撤销）或从未生成过时，链接一律无效
+func checkPreviewSignature(contentType string, id uint64, expires int64, sig, nonce string, now time.Time) error {
+	if sig == "" || nonce == "" || now.Unix() > expires {
+		return ErrPreviewInvalid
+	}
+	if !hmac.Equal([]byte(sig), []byte(signPreview(contentType, id, expires, nonce))) {
+		return ErrPreviewInvalid
+	}
+	return nil
+}
+
+func previewContentStatus(contentType string, id uint64) (string, error) {
+	switch contentType {
+	case PreviewTypePost:
+		post, err := dao.GetPostByID(id)
+		if err != nil {
+			return "", err
+		}
+		return post.Status, nil
+	case PreviewTypePage:
+		page, err := dao.GetPageByID(id)
+		if err != nil {
+			return "", err
+		}
+		return page.Status, nil
+	case PreviewTypeMoment:
+		moment, err := dao.GetMomentByID(id)
+		if err != nil {
+			return "", err
+		}
+		return moment.Status, nil
+	default:
+		return "", ErrPreviewUnsupported
+	}
+}
+
+func isPreviewType(contentType string) bool {
+	return contentType == PreviewTypePost || contentType == PreviewTypePage || contentType == PreviewTypeMoment
+}
+
+func signPreview(contentType string, id uint64, expires int64, nonce string) string {
+	mac := hmac.New(sha256.New, signingSecret("PREVIEW_SECRET", config.Load().PreviewSecret))
+	mac.Write([]byte(contentType + ":" + strconv.FormatUint(id, 10) + ":" + strconv.FormatInt(expires, 10) + ":" + nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newPreviewNonce() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("generate preview nonce: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
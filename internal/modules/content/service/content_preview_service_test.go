@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPreviewLinkVerify(t *testing.T) {
+	now := time.Now()
+	nonce := newPreviewNonce()
+	link := newPreviewLink(PreviewTypePost, 5, now.Add(time.Hour).Truncate(time.Second), nonce)
+
+	// 链接中的参数与返回字段一致，客户端按 URL 访问即可通过校验
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Query().Get("sig") != link.Signature || u.Query().Get("expires") != strconv.FormatInt(link.Expires, 10) {
+		t.Fatalf("url %q does not carry expires/sig", link.URL)
+	}
+
+	// 撤销即轮换签名密钥，之前签发的链接随之失效
+	rotated := newPreviewNonce()
+	if rotated == nonce {
+		t.Fatal("newPreviewNonce returned the same nonce twice")
+	}
+	tampered := "A" + link.Signature[1:]
+	if tampered == link.Signature {
+		tampered = "B" + link.Signature[1:]
+	}
+
+	cases := []struct {
+		name        string
+		contentType string
+		id          uint64
+		expires     int64
+		sig         string
+		nonce       string
+		now         time.Time
+		wantErr     error
+	}{
+		{"有效链接", PreviewTypePost, 5, link.Expires, link.Signature, nonce, now, nil},
+		{"已过期", PreviewTypePost, 5, link.Expires, link.Signature, nonce, link.ExpiresAt.Add(time.Second), ErrPreviewInvalid},
+		{"延长过期时间", PreviewTypePost, 5, link.Expires + 3600, link.Signature, nonce, now, ErrPreviewInvalid},
+		{"签名被篡改", PreviewTypePost, 5, link.Expires, tampered, nonce, now, ErrPreviewInvalid},
+		{"缺少签名", PreviewTypePost, 5, link.Expires, "", nonce, now, ErrPreviewInvalid},
+		{"用于其他内容", PreviewTypePost, 6, link.Expires, link.Signature, nonce, now, ErrPreviewInvalid},
+		{"用于其他类型", PreviewTypePage, 5, link.Expires, link.Signature, nonce, now, ErrPreviewInvalid},
+		{"已撤销", PreviewTypePost, 5, link.Expires, link.Signature, rotated, now, ErrPreviewInvalid},
+		{"从未生成过密钥", PreviewTypePost, 5, link.Expires, link.Signature, "", now, ErrPreviewInvalid},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkPreviewSignature(tc.contentType, tc.id, tc.expires, tc.sig, tc.nonce, tc.now)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("checkPreviewSignature() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+
+	// 类型校验在查询密钥之前完成
+	if err := VerifyPreviewLink("comment", 5, link.Expires, link.Signature); !errors.Is(err, ErrPreviewUnsupported) {
+		t.Fatalf("VerifyPreviewLink(comment) error = %v, want ErrPreviewUnsupported", err)
+	}
+	if err := VerifyPreviewLink(PreviewTypePost, 5, now.Add(-time.Second).Unix(), link.Signature); !errors.Is(err, ErrPreviewInvalid) {
+		t.Fatalf("VerifyPreviewLink(expired) error = %v, want ErrPreviewInvalid", err)
+	}
+}
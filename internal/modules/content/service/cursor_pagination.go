@@ -0,0 +1,163 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	cursorKindPost    = "post"
+	cursorKindComment = "comment"
+)
+
+var ErrInvalidCursor = errors.New("无效的分页游标")
+
+// pageCursor 游标内容：记录一条边界记录的排序键，编码后对客户端不透明。
+// 同时记录列表类型与排序方向，避免游标被用在不匹配的列表上。
+type pageCursor struct {
+	Kind   string     `json:"k"`
+	Sort   string     `json:"s"`
+	Time   *time.Time `json:"t,omitempty"`
+	ID     uint64     `json:"i"`
+	Before bool       `json:"b,omitempty"`
+}
+
+// PostCursorResponse 文章游标分页结果；没有更多数据时对应的游标为空
+type PostCursorResponse struct {
+	Posts      []models.PostWithRelations `json:"posts"`
+	PageSize   int                        `json:"page_size"`
+	NextCursor string                     `json:"next_cursor"`
+	PrevCursor string                     `json:"prev_cursor"`
+}
+
+// CommentCursorResponse 评论游标分页结果
+type CommentCursorResponse struct {
+	Comments   []models.Comment `json:"comments"`
+	PageSize   int              `json:"page_size"`
+	NextCursor string           `json:"next_cursor"`
+	PrevCursor string           `json:"prev_cursor"`
+}
+
+// ListPostsByCursor 按 (published_at, id) 游标分页查询文章，不统计总数；cursor 为空时返回第一页
+func ListPostsByCursor(cursor string, pageSize int, q, sort, category, tag, author, status, visibility, lang string) (*PostCursorResponse, error) {
+	pageSize = clampPageSize(pageSize, 10)
+	sort = normalizeSortOrder(sort)
+	rows, next, prev, err := keysetPage(cursor, cursorKindPost, sort, pageSize,
+		func(key *dao.Keyset, limit int) ([]dao.KeysetRow, error) {
+			return dao.ListPostKeysByKeyset(q, sort, category, tag, author, status, visibility, lang, key, limit)
+		},
+		func(row dao.KeysetRow) (uint64, *time.Time) { return row.ID, row.SortTime },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	posts, err := dao.ListPostsByOrderedIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return &PostCursorResponse{Posts: posts, PageSize: pageSize, NextCursor: next, PrevCursor: prev}, nil
+}
+
+// ListCommentsByCursor 按 (created_at, id) 游标分页查询评论，筛选条件同 ListCommentsWithPagination
+func ListCommentsByCursor(cursor string, pageSize int, postID uint64, status, q, sort string) (*CommentCursorResponse, error) {
+	pageSize = clampPageSize(pageSize, 20)
+	sort = normalizeSortOrder(sort)
+	comments, next, prev, err := keysetPage(cursor, cursorKindComment, sort, pageSize,
+		func(key *dao.Keyset, limit int) ([]models.Comment, error) {
+			return dao.ListCommentsByKeyset(postID, status, q, sort == "DESC", key, limit)
+		},
+		func(c models.Comment) (uint64, *time.Time) { return c.ID, &c.CreatedAt },
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &CommentCursorResponse{Comments: comments, PageSize: pageSize, NextCursor: next, PrevCursor: prev}, nil
+}
+
+// keysetPage 取游标位置之后（或之前）的一页：多取一条判断是否还有更多，
+// 向前翻页时查询结果是倒序的，需要反转回正向顺序
+func keysetPage[T any](cursor, kind, sort string, pageSize int,
+	fetch func(key *dao.Keyset, limit int) ([]T, error),
+	keyOf func(T) (uint64, *time.Time),
+) (items []T, next, prev string, err error) {
+	key, err := decodeCursor(cursor, kind, sort)
+	if err != nil {
+		return nil, "", "", err
+	}
+	items, err = fetch(key, pageSize+1)
+	if err != nil {
+		return nil, "", "", err
+	}
+	hasMore := len(items) > pageSize
+	if hasMore {
+		items = items[:pageSize]
+	}
+	backward := key != nil && key.Before
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	if len(items) == 0 {
+		return items, "", "", nil
+	}
+
+	firstID, firstTime := keyOf(items[0])
+	lastID, lastTime := keyOf(items[len(items)-1])
+	// 向后翻页时，带游标说明前面还有数据；向前翻页时，后面一定还有数据
+	if hasMore || backward {
+		next = encodeCursor(pageCursor{Kind: kind, Sort: sort, Time: lastTime, ID: lastID})
+	}
+	if (hasMore && backward) || (key != nil && !backward) {
+		prev = encodeCursor(pageCursor{Kind: kind, Sort: sort, Time: firstTime, ID: firstID, Before: true})
+	}
+	return items, next, prev, nil
+}
+
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor 解析游标，空字符串表示第一页
+func decodeCursor(token, kind, sort string) (*dao.Keyset, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Kind != kind || c.Sort != sort || c.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &dao.Keyset{Time: c.Time, ID: c.ID, Before: c.Before}, nil
+}
+
+func normalizeSortOrder(sort string) string {
+	if strings.EqualFold(strings.TrimSpace(sort), "ASC") {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+func clampPageSize(pageSize, fallback int) int {
+	if pageSize < 1 {
+		return fallback
+	}
+	if pageSize > 100 {
+		return 100
+	}
+	return pageSize
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"api/internal/modules/content/dao"
+)
+
+// keysetSource 模拟按 ID 正序排列的数据表，fetch 的行为与 dao 中的 keyset 查询一致：
+// 向后翻页取游标之后的记录，向前翻页倒序取游标之前的记录
+func keysetSource(ids []uint64) func(key *dao.Keyset, limit int) ([]uint64, error) {
+	return func(key *dao.Keyset, limit int) ([]uint64, error) {
+		var rows []uint64
+		switch {
+		case key == nil:
+			rows = append(rows, ids...)
+		case key.Before:
+			for i := len(ids) - 1; i >= 0; i-- {
+				if ids[i] < key.ID {
+					rows = append(rows, ids[i])
+				}
+			}
+		default:
+			for _, id := range ids {
+				if id > key.ID {
+					rows = append(rows, id)
+				}
+			}
+		}
+		if len(rows) > limit {
+			rows = rows[:limit]
+		}
+		return rows, nil
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	fetch := keysetSource([]uint64{1, 2, 3, 4, 5})
+	keyOf := func(id uint64) (uint64, *time.Time) { return id, &at }
+	page := func(cursor string) ([]uint64, string, string) {
+		t.Helper()
+		items, next, prev, err := keysetPage(cursor, cursorKindPost, "ASC", 2, fetch, keyOf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return items, next, prev
+	}
+
+	first, next, prev := page("")
+	if !reflect.DeepEqual(first, []uint64{1, 2}) || next == "" || prev != "" {
+		t.Fatalf("first page = %v, next %q, prev %q", first, next, prev)
+	}
+	second, next, prev := page(next)
+	if !reflect.DeepEqual(second, []uint64{3, 4}) || next == "" || prev == "" {
+		t.Fatalf("second page = %v, next %q, prev %q", second, next, prev)
+	}
+	last, next, lastPrev := page(next)
+	if !reflect.DeepEqual(last, []uint64{5}) || next != "" || lastPrev == "" {
+		t.Fatalf("last page = %v, next %q, prev %q", last, next, lastPrev)
+	}
+
+	// 从最后一页向前翻，结果保持正向顺序，回到第一页时没有上一页
+	back, next, prev := page(lastPrev)
+	if !reflect.DeepEqual(back, []uint64{3, 4}) || next == "" || prev == "" {
+		t.Fatalf("back to second page = %v, next %q, prev %q", back, next, prev)
+	}
+	back, next, prev = page(prev)
+	if !reflect.DeepEqual(back, []uint64{1, 2}) || next == "" || prev != "" {
+		t.Fatalf("back to first page = %v, next %q, prev %q", back, next, prev)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	at := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	key, err := decodeCursor(encodeCursor(pageCursor{Kind: cursorKindPost, Sort: "DESC", Time: &at, ID: 42, Before: true}), cursorKindPost, "DESC")
+	if err != nil || key.ID != 42 || !key.Before || key.Time == nil || !key.Time.Equal(at) {
+		t.Fatalf("decodeCursor() = %+v, %v", key, err)
+	}
+	if key, err := decodeCursor("", cursorKindPost, "DESC"); key != nil || err != nil {
+		t.Fatalf("empty cursor = %+v, %v, want first page", key, err)
+	}
+
+	valid := encodeCursor(pageCursor{Kind: cursorKindPost, Sort: "DESC", ID: 1})
+	cases := []struct {
+		name  string
+		token string
+		kind  string
+		sort  string
+	}{
+		{"用在评论列表", valid, cursorKindComment, "DESC"},
+		{"排序方向已改变", valid, cursorKindPost, "ASC"},
+		{"非 base64", "!!not-base64!!", cursorKindPost, "DESC"},
+		{"非 JSON", base64.RawURLEncoding.EncodeToString([]byte("hello")), cursorKindPost, "DESC"},
+		{"缺少 ID", encodeCursor(pageCursor{Kind: cursorKindPost, Sort: "DESC"}), cursorKindPost, "DESC"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := decodeCursor(tc.token, tc.kind, tc.sort); !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("decodeCursor() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
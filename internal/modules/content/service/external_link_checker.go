@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultLinkCheckTimeout = 10 * time.Second
+
+// ExternalLinkChecker 外部链接检查器：返回目标地址的 HTTP 状态码，无法访问时返回错误。
+// 可替换为其他实现（如只检查白名单域名、读取缓存结果）
+type ExternalLinkChecker interface {
+	Check(ctx context.Context, rawURL string) (int, error)
+}
+
+// HTTPLinkChecker 通过 HTTP 请求检查外部链接：先发 HEAD，服务器不支持时改用 GET。
+// Endpoint 不为空时所有请求改发到该地址（保留原路径与查询参数，Host 头仍为原站点），
+// 用于在测试环境中指向本地桩服务
+type HTTPLinkChecker struct {
+	Client    *http.Client
+	Endpoint  string
+	UserAgent string
+}
+
+// NewHTTPLinkChecker 创建带超时的 HTTP 检查器
+func NewHTTPLinkChecker(timeout time.Duration, endpoint string) *HTTPLinkChecker {
+	if timeout <= 0 {
+		timeout = defaultLinkCheckTimeout
+	}
+	return &HTTPLinkChecker{
+		Client:    &http.Client{Timeout: timeout},
+		Endpoint:  strings.TrimRight(strings.TrimSpace(endpoint), "/"),
+		UserAgent: "blog-link-checker/1.0",
+	}
+}
+
+func (h *HTTPLinkChecker) Check(ctx context.Context, rawURL string) (int, error) {
+	code, err := h.do(ctx, http.MethodHead, rawURL)
+	if err == nil && (code == http.StatusMethodNotAllowed || code == http.StatusNotImplemented) {
+		code, err = h.do(ctx, http.MethodGet, rawURL)
+	}
+	return code, err
+}
+
+func (h *HTTPLinkChecker) do(ctx context.Context, method, rawURL string) (int, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, err
+	}
+	host := target.Host
+	if h.Endpoint != "" {
+		endpoint, err := url.Parse(h.Endpoint)
+		if err != nil {
+			return 0, fmt.Errorf("无效的检查地址: %w", err)
+		}
+		rewritten := *target
+		rewritten.Scheme = endpoint.Scheme
+		rewritten.Host = endpoint.Host
+		rewritten.Path = endpoint.Path + target.Path
+		rewritten.RawPath = ""
+		target = &rewritten
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Host = host
+	if h.UserAgent != "" {
+		req.Header.Set("User-Agent", h.UserAgent)
+	}
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultLinkCheckTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,633 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"api/internal/modules/media"
+	"api/internal/platform/redisstore"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+	FeedFormatJSON = "json"
+
+	FeedScopeAll      = ""
+	FeedScopeCategory = "category"
+	FeedScopeTag      = "tag"
+
+	defaultFeedItemLimit = 20
+	maxFeedItemLimit     = 100
+	feedSummaryRunes     = 200
+)
+
+var (
+	ErrFeedFormat = errors.New("format 只能是 rss、atom 或 json")
+	ErrFeedLang   = errors.New("没有该语言的文章")
+)
+
+var feedContentTypes = map[string]string{
+	FeedFormatRSS:  "application/rss+xml; charset=utf-8",
+	FeedFormatAtom: "application/atom+xml; charset=utf-8",
+	FeedFormatJSON: "application/feed+json; charset=utf-8",
+}
+
+// Feed 渲染好的订阅源
+type Feed struct {
+	Body         []byte
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+
+	expiresAt time.Time
+}
+
+// 订阅源缓存：启用 Redis 时按版本号缓存渲染结果，InvalidateFeeds 递增版本让所有实例同时失效；
+// 未启用 Redis 时使用进程内缓存。key 为 scope:slug:format:lang
+const (
+	feedGenerationKey  = "feeds:generation"
+	feedCacheKeyPrefix = "feeds:"
+)
+
+var (
+	feedCacheMu         sync.Mutex
+	feedCacheGeneration int64
+	feedCache           = make(map[string]*Feed)
+)
+
+// GetFeed 获取全站、分类或标签的订阅源，只包含已发布且公开可见的文章；lang 不为空时只包含该语言的文章
+func GetFeed(scope, slug, format, lang string) (*Feed, error) {
+	contentType, ok := feedContentTypes[format]
+	if !ok {
+		return nil, ErrFeedFormat
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	key := scope + ":" + slug + ":" + format + ":" + lang
+	now := time.Now()
+	client, redisKey := feedRedisKey(ctx, key)
+	var localGeneration int64
+	if client != nil {
+		var cached Feed
+		value, err := client.Get(ctx, redisKey).Bytes()
+		if err == nil && json.Unmarshal(value, &cached) == nil {
+			return &cached, nil
+		}
+		if err != nil && err != redis.Nil {
+			fmt.Printf("[feeds] redis get error: %v\n", err)
+		}
+	} else {
+		feedCacheMu.Lock()
+		cached := feedCache[key]
+		localGeneration = feedCacheGeneration
+		feedCacheMu.Unlock()
+		if cached != nil && now.Before(cached.expiresAt) {
+			return cached, nil
+		}
+	}
+
+	// lang 来自请求参数，只为确实有文章的语言生成并缓存订阅源，避免任意取值撑大缓存
+	if lang != "" && lang != DefaultLang() {
+		exists, err := dao.PublicPostLangExists(lang)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrFeedLang
+		}
+	}
+
+	channel, err := loadFeedChannel(scope, slug, format, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	switch format {
+	case FeedFormatRSS:
+		body, err = renderRSSFeed(channel)
+	case FeedFormatAtom:
+		body, err = renderAtomFeed(channel)
+	default:
+		body, err = renderJSONFeed(channel)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(body)
+	ttl := config.Load().FeedCacheTTL
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	feed := &Feed{
+		Body:         body,
+		ContentType:  contentType,
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: channel.Updated,
+		expiresAt:    now.Add(ttl),
+	}
+
+	if client != nil {
+		payload, err := json.Marshal(feed)
+		if err == nil {
+			err = client.Set(ctx, redisKey, payload, ttl).Err()
+		}
+		if err != nil {
+			fmt.Printf("[feeds] redis set error: %v\n", err)
+		}
+		return feed, nil
+	}
+	feedCacheMu.Lock()
+	// 生成期间缓存已失效时不写回，避免旧内容覆盖
+	if localGeneration == feedCacheGeneration {
+		feedCache[key] = feed
+	}
+	feedCacheMu.Unlock()
+	return feed, nil
+}
+
+// feedRedisKey 返回带当前版本号的缓存键；未启用 Redis 或读取版本失败时 client 为 nil
+func feedRedisKey(ctx context.Context, key string) (*redis.Client, string) {
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		return nil, ""
+	}
+	generation, err := client.Get(ctx, feedGenerationKey).Int64()
+	if err != nil && err != redis.Nil {
+		fmt.Printf("[feeds] redis get generation error: %v\n", err)
+		return nil, ""
+	}
+	return client, feedCacheKeyPrefix + strconv.FormatInt(generation, 10) + ":" + key
+}
+
+// InvalidateFeeds 使所有实例的订阅源缓存失效
+func InvalidateFeeds() {
+	feedCacheMu.Lock()
+	feedCacheGeneration++
+	feedCache = make(map[string]*Feed)
+	feedCacheMu.Unlock()
+
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Incr(ctx, feedGenerationKey).Err(); err != nil {
+		fmt.Printf("[feeds] redis incr generation error: %v\n", err)
+	}
+}
+
+type feedChannel struct {
+	Title       string
+	Description string
+	Lang        string
+	HomeURL     string
+	FeedURL     string
+	Updated     time.Time
+	Items       []feedItem
+}
+
+type feedItem struct {
+	ID          uint64
+	Title       string
+	URL         string
+	GUID        string // 条目标识，按文章ID生成，修改 slug 后阅读器不会当作新文章
+	Summary     string
+	ContentHTML string
+	Image       string
+	Published   time.Time
+	Updated     time.Time
+	Categories  []string
+	Lang        string
+	Alternates  []HrefLangAlternate // 各语言版本（含自身），没有翻译时为空
+}
+
+func loadFeedChannel(scope, slug, format, lang string) (*feedChannel, error) {
+	cfg := config.Load()
+	baseURL := strings.TrimRight(config.GetBaseURL(), "/")
+
+	channel := &feedChannel{
+		Title:       cfg.SiteTitle,
+		Description: cfg.SiteDescription,
+		Lang:        lang,
+		HomeURL:     baseURL + "/",
+	}
+	if channel.Lang == "" {
+		channel.Lang = DefaultLang()
+	}
+
+	var category, tag string
+	var scopeUpdated time.Time
+	switch scope {
+	case FeedScopeAll:
+		channel.FeedURL = baseURL + "/api/feeds/" + format
+	case FeedScopeCategory:
+		c, err := GetCategoryBySlug(slug)
+		if err != nil {
+			return nil, err
+		}
+		category = c.Slug
+		channel.Title = cfg.SiteTitle + " - " + c.Name
+		if c.Description != "" {
+			channel.Description = c.Description
+		}
+		channel.FeedURL = baseURL + "/api/feeds/category/" + c.Slug + "/" + format
+		scopeUpdated = c.UpdatedAt
+	case FeedScopeTag:
+		t, err := GetTagBySlug(slug)
+		if err != nil {
+			return nil, err
+		}
+		tag = t.Slug
+		channel.Title = cfg.SiteTitle + " - " + t.Name
+		if t.Description != "" {
+			channel.Description = t.Description
+		}
+		channel.FeedURL = baseURL + "/api/feeds/tag/" + t.Slug + "/" + format
+		scopeUpdated = t.CreatedAt // 标签没有更新时间
+	default:
+		return nil, ErrFeedFormat
+	}
+	if channel.Description == "" {
+		channel.Description = channel.Title
+	}
+	if lang != "" {
+		channel.FeedURL += "?lang=" + url.QueryEscape(lang)
+	}
+
+	limit := cfg.FeedItemLimit
+	if limit <= 0 {
+		limit = defaultFeedItemLimit
+	}
+	if limit > maxFeedItemLimit {
+		limit = maxFeedItemLimit
+	}
+
+	result, err := ListPostsWithPagination(1, limit, "", "DESC", category, tag, "", "published", PostScopePublic, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	channel.Updated = scopeUpdated
+	channel.Items = make([]feedItem, 0, len(result.Posts))
+	for i := range result.Posts {
+		post := &result.Posts[i].Post
+		item := feedItem{
+			ID:         post.ID,
+			Title:      post.Title,
+			URL:        postURL(baseURL, post.Slug),
+			GUID:       baseURL + "/posts/" + strconv.FormatUint(post.ID, 10),
+			Summary:    contentSummary(post.Excerpt, post.Content, feedSummaryRunes),
+			Published:  post.CreatedAt,
+			Updated:    post.UpdatedAt,
+			Categories: append(append([]string{}, post.CategoryNames...), post.TagNames...),
+			Lang:       post.Lang,
+		}
+		if post.PublishedAt != nil {
+			item.Published = *post.PublishedAt
+		}
+		if item.Updated.Before(item.Published) {
+			item.Updated = item.Published
+		}
+		if post.CoverImage != "" {
+			item.Image = media.GetFullFileURL(post.CoverImage)
+		}
+		if cfg.FeedFullContent {
+			item.ContentHTML = RenderPostContent(post).HTML
+		}
+		if item.Updated.After(channel.Updated) {
+			channel.Updated = item.Updated
+		}
+		channel.Items = append(channel.Items, item)
+	}
+	if err := attachFeedAlternates(channel, result.Posts, baseURL); err != nil {
+		return nil, err
+	}
+	if channel.Updated.IsZero() {
+		channel.Updated = time.Now()
+	}
+	// HTTP 日期精确到秒，截断后才能与 If-Modified-Since 比较
+	channel.Updated = channel.Updated.UTC().Truncate(time.Second)
+	return channel, nil
+}
+
+// attachFeedAlternates 为有其他语言版本的条目生成 hreflang 备用链接，只指向访客可见的版本
+func attachFeedAlternates(channel *feedChannel, posts []models.PostWithRelations, baseURL string) error {
+	groups := make([]string, 0, len(posts))
+	for i := range posts {
+		if group := posts[i].TranslationGroup; group != "" {
+			groups = append(groups, group)
+		}
+	}
+	members, err := dao.ListPublicPostTranslations(groups)
+	if err != nil {
+		return err
+	}
+	byGroup := make(map[string][]models.TranslationLink)
+	for _, member := range members {
+		byGroup[member.TranslationGroup] = append(byGroup[member.TranslationGroup], member.TranslationLink)
+	}
+	for i := range channel.Items {
+		item := &channel.Items[i]
+		others := excludeTranslation(byGroup[posts[i].TranslationGroup], item.ID)
+		item.Alternates = hrefLangAlternates(item.Lang, item.URL, others, func(link models.TranslationLink) string {
+			return postURL(baseURL, link.Slug)
+		})
+	}
+	return nil
+}
+
+// ---- RSS 2.0 ----
+
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	Language      string      `xml:"language,omitempty"`
+	LastBuildDate string      `xml:"lastBuildDate"`
+	AtomLink      rssAtomLink `xml:"atom:link"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href     string `xml:"href,attr"`
+	Rel      string `xml:"rel,attr"`
+	Type     string `xml:"type,attr"`
+	HrefLang string `xml:"hreflang,attr,omitempty"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Description string        `xml:"description"`
+	Content     *xmlCDATA     `xml:"content:encoded,omitempty"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+	Alternates  []rssAtomLink `xml:"atom:link"` // 其他语言版本
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type xmlCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+func renderRSSFeed(channel *feedChannel) ([]byte, error) {
+	doc := rssFeed{
+		Version:   "2.0",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		Channel: rssChannel{
+			Title:         channel.Title,
+			Link:          channel.HomeURL,
+			Description:   channel.Description,
+			Language:      channel.Lang,
+			LastBuildDate: channel.Updated.Format(time.RFC1123Z),
+			AtomLink:      rssAtomLink{Href: channel.FeedURL, Rel: "self", Type: "application/rss+xml"},
+			Items:         make([]rssItem, 0, len(channel.Items)),
+		},
+	}
+	for _, item := range channel.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.URL,
+			GUID:        rssGUID{IsPermaLink: "false", Value: item.GUID},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Description: item.Summary,
+			Categories:  item.Categories,
+		}
+		if item.ContentHTML != "" {
+			entry.Content = &xmlCDATA{Value: item.ContentHTML}
+		}
+		if item.Image != "" {
+			entry.Enclosure = &rssEnclosure{URL: item.Image, Length: "0", Type: imageMIMEType(item.Image)}
+		}
+		for _, alt := range item.Alternates {
+			entry.Alternates = append(entry.Alternates, rssAtomLink{Href: alt.URL, Rel: "alternate", Type: "text/html", HrefLang: alt.Lang})
+		}
+		doc.Channel.Items = append(doc.Channel.Items, entry)
+	}
+	return marshalXMLDocument(doc)
+}
+
+// ---- Atom 1.0 ----
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	NS       string      `xml:"xmlns,attr"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomPerson  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href     string `xml:"href,attr"`
+	Rel      string `xml:"rel,attr,omitempty"`
+	Type     string `xml:"type,attr,omitempty"`
+	HrefLang string `xml:"hreflang,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Lang       string         `xml:"xml:lang,attr,omitempty"`
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+func renderAtomFeed(channel *feedChannel) ([]byte, error) {
+	doc := atomFeed{
+		NS:       "http://www.w3.org/2005/Atom",
+		Lang:     channel.Lang,
+		Title:    channel.Title,
+		Subtitle: channel.Description,
+		ID:       channel.FeedURL,
+		Updated:  channel.Updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: channel.FeedURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: channel.HomeURL, Rel: "alternate", Type: "text/html"},
+		},
+		Author:  atomPerson{Name: channel.Title},
+		Entries: make([]atomEntry, 0, len(channel.Items)),
+	}
+	for _, item := range channel.Items {
+		entry := atomEntry{
+			Lang:      item.Lang,
+			Title:     item.Title,
+			ID:        item.GUID,
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+		}
+		if len(item.Alternates) == 0 {
+			entry.Links = []atomLink{{Href: item.URL, Rel: "alternate", Type: "text/html"}}
+		}
+		// 同为 alternate 的链接需以 hreflang 区分，自身也在其中
+		for _, alt := range item.Alternates {
+			entry.Links = append(entry.Links, atomLink{Href: alt.URL, Rel: "alternate", Type: "text/html", HrefLang: alt.Lang})
+		}
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: item.Summary}
+		}
+		if item.ContentHTML != "" {
+			entry.Content = &atomText{Type: "html", Value: item.ContentHTML}
+		}
+		for _, name := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: name})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return marshalXMLDocument(doc)
+}
+
+func marshalXMLDocument(doc interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// ---- JSON Feed 1.1 ----
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description,omitempty"`
+	Language    string         `json:"language,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string             `json:"id"`
+	URL           string             `json:"url"`
+	Title         string             `json:"title"`
+	Summary       string             `json:"summary,omitempty"`
+	ContentHTML   string             `json:"content_html,omitempty"`
+	ContentText   string             `json:"content_text,omitempty"`
+	Image         string             `json:"image,omitempty"`
+	DatePublished string             `json:"date_published"`
+	DateModified  string             `json:"date_modified"`
+	Tags          []string           `json:"tags,omitempty"`
+	Language      string             `json:"language,omitempty"`
+	HrefLang      *jsonFeedAlternate `json:"_hreflang,omitempty"` // 扩展字段：其他语言版本
+}
+
+type jsonFeedAlternate struct {
+	Alternates []HrefLangAlternate `json:"alternates"`
+}
+
+func renderJSONFeed(channel *feedChannel) ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       channel.Title,
+		HomePageURL: channel.HomeURL,
+		FeedURL:     channel.FeedURL,
+		Description: channel.Description,
+		Language:    channel.Lang,
+		Items:       make([]jsonFeedItem, 0, len(channel.Items)),
+	}
+	for _, item := range channel.Items {
+		entry := jsonFeedItem{
+			ID:            strconv.FormatUint(item.ID, 10),
+			URL:           item.URL,
+			Title:         item.Title,
+			Summary:       item.Summary,
+			ContentHTML:   item.ContentHTML,
+			Image:         item.Image,
+			DatePublished: item.Published.UTC().Format(time.RFC3339),
+			DateModified:  item.Updated.UTC().Format(time.RFC3339),
+			Tags:          item.Categories,
+			Language:      item.Lang,
+		}
+		if len(item.Alternates) > 0 {
+			entry.HrefLang = &jsonFeedAlternate{Alternates: item.Alternates}
+		}
+		// JSON Feed 要求 content_html 与 content_text 至少有一个
+		if entry.ContentHTML == "" {
+			entry.ContentText = item.Summary
+		}
+		doc.Items = append(doc.Items, entry)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func imageMIMEType(url string) string {
+	lower := strings.ToLower(url)
+	if i := strings.IndexAny(lower, "?#"); i >= 0 {
+		lower = lower[:i]
+	}
+	switch {
+	case strings.HasSuffix(lower, ".png"):
+		return "image/png"
+	case strings.HasSuffix(lower, ".gif"):
+		return "image/gif"
+	case strings.HasSuffix(lower, ".webp"):
+		return "image/webp"
+	case strings.HasSuffix(lower, ".svg"):
+		return "image/svg+xml"
+	default:
+		return "image/jpeg"
+	}
+}
@@ -0,0 +1,411 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"api/internal/modules/media"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 链接类型
+const (
+	LinkKindUpload   = "upload"
+	LinkKindPost     = "post"
+	LinkKindPage     = "page"
+	LinkKindExternal = "external"
+)
+
+const defaultLinkCheckConcurrency = 8
+
+// LinkCheckOptions 检查选项；External 为 false 时只检查站内链接与上传文件
+type LinkCheckOptions struct {
+	External    bool
+	Checker     ExternalLinkChecker // 为空时使用按配置创建的 HTTPLinkChecker
+	Concurrency int                 // 外部链接并发数
+}
+
+// LinkIssue 一条失效的引用
+type LinkIssue struct {
+	SourceType  string `json:"source_type"` // post/page/moment
+	SourceID    uint64 `json:"source_id"`
+	SourceTitle string `json:"source_title"`
+	URL         string `json:"url"`
+	Kind        string `json:"kind"`
+	Reason      string `json:"reason"`
+	StatusCode  int    `json:"status_code,omitempty"`
+}
+
+// LinkCheckReport 检查报告
+type LinkCheckReport struct {
+	Posts     int         `json:"posts"`
+	Pages     int         `json:"pages"`
+	Moments   int         `json:"moments"`
+	Links     int         `json:"links"`    // 检查的引用数（同一内容中的重复地址只计一次）
+	External  int         `json:"external"` // 检查的外部地址数（全站去重）
+	Broken    []LinkIssue `json:"broken"`
+	CheckedAt time.Time   `json:"checked_at"`
+}
+
+var (
+	markdownLinkPattern  = regexp.MustCompile(`\]\(\s*<?([^)\s>]+)>?(?:\s+["'(][^)]*)?\)`)
+	markdownRefPattern   = regexp.MustCompile(`(?m)^\s{0,3}\[[^\]]+\]:\s*<?([^\s>]+)>?`)
+	htmlAttrLinkPattern  = regexp.MustCompile(`(?i)\b(?:href|src)\s*=\s*["']([^"']+)["']`)
+	bareExternalPattern  = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+	trailingPunctuations = ".,;:!?，。；：！？"
+)
+
+// linkRef 从内容中提取出的一条引用
+type linkRef struct {
+	sourceType  string
+	sourceID    uint64
+	sourceTitle string
+	url         string
+	kind        string
+	target      string // 上传文件路径、文章ID/slug 或页面 slug
+}
+
+// CheckContentLinks 扫描文章、页面、动态中的站内链接、上传文件引用与（可选的）外部链接，报告失效的引用。
+// 回收站中的文章不参与扫描；链接到未发布、私密或已删除文章与页面的引用视为失效
+func CheckContentLinks(ctx context.Context, opts LinkCheckOptions) (*LinkCheckReport, error) {
+	posts, err := dao.ListPosts()
+	if err != nil {
+		return nil, err
+	}
+	pages, err := dao.ListPages()
+	if err != nil {
+		return nil, err
+	}
+	moments, err := dao.ListMoments("", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LinkCheckReport{Broken: []LinkIssue{}, CheckedAt: time.Now()}
+	extractor := newLinkExtractor()
+	var refs []linkRef
+	for i := range posts {
+		post := &posts[i]
+		if post.Status == StatusTrash {
+			continue
+		}
+		report.Posts++
+		refs = append(refs, extractor.extract("post", post.ID, post.Title, post.Content, post.CoverImage, post.OGImage)...)
+	}
+	for i := range pages {
+		page := &pages[i]
+		report.Pages++
+		refs = append(refs, extractor.extract("page", page.ID, page.Title, page.Content, page.OGImage)...)
+	}
+	for i := range moments {
+		moment := &moments[i]
+		report.Moments++
+		refs = append(refs, extractor.extract("moment", moment.ID, momentTitle(moment), moment.Content, moment.Images...)...)
+	}
+
+	resolver := newInternalLinkResolver(posts, pages)
+	var external []linkRef
+	for _, ref := range refs {
+		if ref.kind == LinkKindExternal {
+			if opts.External {
+				report.Links++
+				external = append(external, ref)
+			}
+			continue
+		}
+		report.Links++
+		if reason := resolver.check(ref); reason != "" {
+			report.Broken = append(report.Broken, ref.issue(reason, 0))
+		}
+	}
+
+	if len(external) > 0 {
+		issues, checked := checkExternalLinks(ctx, external, opts)
+		report.External = checked
+		report.Broken = append(report.Broken, issues...)
+	}
+
+	sort.SliceStable(report.Broken, func(i, j int) bool {
+		a, b := report.Broken[i], report.Broken[j]
+		if a.SourceType != b.SourceType {
+			return a.SourceType < b.SourceType
+		}
+		if a.SourceID != b.SourceID {
+			return a.SourceID < b.SourceID
+		}
+		return a.URL < b.URL
+	})
+	return report, ctx.Err()
+}
+
+func (r linkRef) issue(reason string, statusCode int) LinkIssue {
+	return LinkIssue{
+		SourceType:  r.sourceType,
+		SourceID:    r.sourceID,
+		SourceTitle: r.sourceTitle,
+		URL:         r.url,
+		Kind:        r.kind,
+		Reason:      reason,
+		StatusCode:  statusCode,
+	}
+}
+
+func momentTitle(moment *models.Moment) string {
+	title := []rune(strings.TrimSpace(moment.Content))
+	if len(title) > 30 {
+		return string(title[:30]) + "…"
+	}
+	return string(title)
+}
+
+// linkExtractor 提取 Markdown 链接/图片、引用式链接、HTML href/src 与裸露的外部地址
+type linkExtractor struct {
+	baseURL *url.URL
+}
+
+func newLinkExtractor() *linkExtractor {
+	base, err := url.Parse(strings.TrimSpace(config.GetBaseURL()))
+	if err != nil || base.Host == "" {
+		base = nil
+	}
+	return &linkExtractor{baseURL: base}
+}
+
+// extract 提取一篇内容中的引用，同一内容中的重复地址只保留一次；extras 为封面、配图等单独存放的地址
+func (e *linkExtractor) extract(sourceType string, sourceID uint64, title, content string, extras ...string) []linkRef {
+	seen := make(map[string]bool)
+	var refs []linkRef
+	add := func(raw string) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || seen[raw] {
+			return
+		}
+		seen[raw] = true
+		kind, target, ok := e.classify(raw)
+		if !ok {
+			return
+		}
+		refs = append(refs, linkRef{
+			sourceType:  sourceType,
+			sourceID:    sourceID,
+			sourceTitle: title,
+			url:         raw,
+			kind:        kind,
+			target:      target,
+		})
+	}
+
+	for _, pattern := range []*regexp.Regexp{markdownLinkPattern, markdownRefPattern, htmlAttrLinkPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			add(match[1])
+		}
+	}
+	for _, match := range bareExternalPattern.FindAllString(content, -1) {
+		add(strings.TrimRight(match, trailingPunctuations))
+	}
+	for _, extra := range extras {
+		add(extra)
+	}
+	return refs
+}
+
+// classify 判断链接类型；锚点、mailto、分类标签等其他站内路径不检查。
+// 路径以 /uploads/ 开头的地址不论主机都视为上传文件引用：CDN、旧域名或 API_BASE_URL 配置有误时，
+// 按外部链接处理会让孤立文件清理误删仍在使用的文件
+func (e *linkExtractor) classify(raw string) (kind, target string, ok bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", false
+	}
+	switch {
+	case u.Scheme == "" && u.Host == "":
+		if !strings.HasPrefix(u.Path, "/") {
+			return "", "", false
+		}
+	case u.Scheme == "http" || u.Scheme == "https":
+		if p := path.Clean("/" + u.Path); strings.HasPrefix(p, "/uploads/") {
+			return LinkKindUpload, p, true
+		}
+		if e.baseURL == nil || !strings.EqualFold(u.Host, e.baseURL.Host) {
+			return LinkKindExternal, raw, true
+		}
+	default:
+		return "", "", false
+	}
+
+	p := path.Clean(u.Path)
+	switch {
+	case strings.HasPrefix(p, "/uploads/"):
+		return LinkKindUpload, p, true
+	case strings.HasPrefix(p, "/posts/"):
+		if slug := strings.TrimPrefix(p, "/posts/"); slug != "" && !strings.Contains(slug, "/") {
+			return LinkKindPost, slug, true
+		}
+	case strings.HasPrefix(p, "/pages/"):
+		if slug := strings.TrimPrefix(p, "/pages/"); slug != "" && !strings.Contains(slug, "/") {
+			return LinkKindPage, slug, true
+		}
+	}
+	return "", "", false
+}
+
+// internalLinkResolver 基于一次性加载的文章、页面数据判断站内引用是否有效
+type internalLinkResolver struct {
+	postsByID   map[uint64]*models.Post
+	postsBySlug map[string]*models.Post
+	pages       map[string]*models.Page
+	files       map[string]bool
+}
+
+func newInternalLinkResolver(posts []models.Post, pages []models.Page) *internalLinkResolver {
+	r := &internalLinkResolver{
+		postsByID:   make(map[uint64]*models.Post, len(posts)),
+		postsBySlug: make(map[string]*models.Post, len(posts)),
+		pages:       make(map[string]*models.Page, len(pages)),
+		files:       make(map[string]bool),
+	}
+	for i := range posts {
+		r.postsByID[posts[i].ID] = &posts[i]
+		r.postsBySlug[posts[i].Slug] = &posts[i]
+	}
+	for i := range pages {
+		r.pages[pages[i].Slug] = &pages[i]
+	}
+	return r
+}
+
+// check 返回失效原因，有效时返回空字符串
+func (r *internalLinkResolver) check(ref linkRef) string {
+	switch ref.kind {
+	case LinkKindUpload:
+		if !r.uploadExists(ref.target) {
+			return "文件不存在"
+		}
+	case LinkKindPost:
+		return r.checkPost(ref.target)
+	case LinkKindPage:
+		page, ok := r.pages[ref.target]
+		if !ok {
+			return "页面不存在"
+		}
+		if page.Status != "published" {
+			return "页面未发布"
+		}
+	}
+	return ""
+}
+
+func (r *internalLinkResolver) checkPost(target string) string {
+	post, ok := r.postsBySlug[target]
+	if !ok {
+		if id, err := strconv.ParseUint(target, 10, 64); err == nil {
+			post, ok = r.postsByID[id]
+		}
+	}
+	if !ok {
+		// 历史 slug 会跳转到当前地址，仍然有效
+		record, err := dao.GetPostSlugHistory(target)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "文章不存在"
+			}
+			return "查询失败: " + err.Error()
+		}
+		if post, ok = r.postsByID[record.PostID]; !ok {
+			return "文章不存在"
+		}
+	}
+	switch {
+	case post.Status == StatusTrash:
+		return "文章在回收站中"
+	case post.Status != "published":
+		return "文章未发布"
+	case post.Visibility == VisibilityPrivate:
+		return "文章为私密"
+	}
+	return ""
+}
+
+func (r *internalLinkResolver) uploadExists(ref string) bool {
+	if exists, ok := r.files[ref]; ok {
+		return exists
+	}
+	rel := strings.TrimPrefix(ref, "/uploads/")
+	_, err := os.Stat(filepath.Join(media.UploadDir, filepath.FromSlash(rel)))
+	r.files[ref] = err == nil
+	return err == nil
+}
+
+// checkExternalLinks 并发检查外部地址，同一地址只请求一次；返回失效的引用与实际检查的地址数
+func checkExternalLinks(ctx context.Context, refs []linkRef, opts LinkCheckOptions) ([]LinkIssue, int) {
+	checker := opts.Checker
+	if checker == nil {
+		cfg := config.Load()
+		checker = NewHTTPLinkChecker(cfg.LinkCheckTimeout, cfg.LinkCheckEndpoint)
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultLinkCheckConcurrency
+	}
+
+	type result struct {
+		code int
+		err  error
+	}
+	var urls []string
+	results := make(map[string]*result)
+	for _, ref := range refs {
+		if _, ok := results[ref.url]; !ok {
+			results[ref.url] = &result{}
+			urls = append(urls, ref.url)
+		}
+	}
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(urls); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range jobs {
+				res := results[u]
+				res.code, res.err = checker.Check(ctx, u)
+			}
+		}()
+	}
+	checked := 0
+	for _, u := range urls {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- u
+		checked++
+	}
+	close(jobs)
+	wg.Wait()
+
+	var issues []LinkIssue
+	for _, ref := range refs {
+		res := results[ref.url]
+		switch {
+		case res.err != nil:
+			issues = append(issues, ref.issue("无法访问: "+res.err.Error(), 0))
+		case res.code >= 400:
+			issues = append(issues, ref.issue("HTTP "+strconv.Itoa(res.code), res.code))
+		}
+	}
+	return issues, checked
+}
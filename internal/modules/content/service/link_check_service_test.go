@@ -0,0 +1,40 @@
+package service
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestLinkExtractorClassify(t *testing.T) {
+	base, _ := url.Parse("https://api.example.com")
+	e := &linkExtractor{baseURL: base}
+	cases := []struct {
+		name       string
+		raw        string
+		wantKind   string
+		wantTarget string
+		wantOK     bool
+	}{
+		{"站内上传文件", "/uploads/images/a.png", LinkKindUpload, "/uploads/images/a.png", true},
+		{"本站域名上传文件", "https://api.example.com/uploads/a.png", LinkKindUpload, "/uploads/a.png", true},
+		{"其他域名上传文件", "https://cdn.example.net/uploads/a.png?v=1", LinkKindUpload, "/uploads/a.png", true},
+		{"上传路径规范化", "/uploads/images/../a.png", LinkKindUpload, "/uploads/a.png", true},
+		{"站内文章", "/posts/hello-world", LinkKindPost, "hello-world", true},
+		{"本站域名文章", "https://api.example.com/posts/hello-world", LinkKindPost, "hello-world", true},
+		{"站内页面", "/pages/about", LinkKindPage, "about", true},
+		{"外部链接", "https://other.example.org/posts/x", LinkKindExternal, "https://other.example.org/posts/x", true},
+		{"多级文章路径不检查", "/posts/a/b", "", "", false},
+		{"分类路径不检查", "/categories/go", "", "", false},
+		{"相对路径不检查", "images/a.png", "", "", false},
+		{"锚点不检查", "#top", "", "", false},
+		{"mailto 不检查", "mailto:a@example.com", "", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, target, ok := e.classify(tc.raw)
+			if kind != tc.wantKind || target != tc.wantTarget || ok != tc.wantOK {
+				t.Fatalf("classify(%q) = (%q, %q, %v), want (%q, %q, %v)", tc.raw, kind, target, ok, tc.wantKind, tc.wantTarget, tc.wantOK)
+			}
+		})
+	}
+}
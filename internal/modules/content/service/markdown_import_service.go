@@ -0,0 +1,1031 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"api/internal/modules/media"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// 导入结果动作
+const (
+	ImportActionCreate = "create"
+	ImportActionSkip   = "skip"
+	ImportActionError  = "error"
+)
+
+var (
+	ErrImportNoFrontMatter = errors.New("缺少 front matter")
+	ErrImportNoTitle       = errors.New("front matter 缺少 title")
+	ErrImportNoDate        = errors.New("动态的 front matter 缺少 date")
+
+	mdImageRefPattern     = regexp.MustCompile(`(!\[[^\]]*\]\()\s*<?([^)\s>]+)>?((?:\s+"[^"]*")?\s*\))`)
+	htmlImageRefPattern   = regexp.MustCompile(`(<img\b[^>]*?\bsrc\s*=\s*["'])([^"']+)(["'])`)
+	mdUploadLinkPattern   = regexp.MustCompile(`(\]\()\s*<?(/uploads/[^)\s>]+)>?((?:\s+"[^"]*")?\s*\))`)
+	htmlUploadLinkPattern = regexp.MustCompile(`(<a\b[^>]*?\bhref\s*=\s*["'])(/uploads/[^"']+)(["'])`)
+	jekyllDatePrefix      = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}-`)
+	importSlugPattern     = regexp.MustCompile(`[^a-z0-9]+`)
+	moreMarkerPattern     = regexp.MustCompile(`(?i)<!--\s*more\s*-->`)
+)
+
+// front matter 中的日期格式（无时区时按服务器本地时区）
+var frontMatterDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// MarkdownImportOptions 导入参数
+type MarkdownImportOptions struct {
+	Dir       string // 需要扫描的目录（递归）
+	AuthorID  uint64 // 导入文章的作者
+	StaticDir string // 以 / 开头的图片路径所在的静态目录（Hugo 的 static/、Hexo 的 source/），为空时在 Dir 下查找
+	ImageDir  string // 图片复制目标目录，默认 media.ImageUploadDir
+	DryRun    bool   // 只生成报告，不写数据库、不复制图片
+}
+
+// MarkdownImportItem 单个文件的导入结果
+type MarkdownImportItem struct {
+	File          string   `json:"file"`
+	Action        string   `json:"action"`
+	Message       string   `json:"message,omitempty"`
+	Type          string   `json:"type"`
+	PostID        uint64   `json:"post_id,omitempty"`
+	PageID        uint64   `json:"page_id,omitempty"`
+	MomentID      uint64   `json:"moment_id,omitempty"`
+	Title         string   `json:"title,omitempty"`
+	Slug          string   `json:"slug,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	Categories    []string `json:"categories,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	Images        int      `json:"images,omitempty"`
+	MissingImages []string `json:"missing_images,omitempty"`
+}
+
+// MarkdownImportReport 导入报告
+type MarkdownImportReport struct {
+	DryRun            bool                 `json:"dry_run"`
+	ScannedFiles      int                  `json:"scanned_files"`
+	Created           int                  `json:"created"`
+	Skipped           int                  `json:"skipped"`
+	Failed            int                  `json:"failed"`
+	CopiedImages      int                  `json:"copied_images"`
+	CreatedCategories []string             `json:"created_categories"`
+	CreatedTags       []string             `json:"created_tags"`
+	Items             []MarkdownImportItem `json:"items"`
+}
+
+// importedMarkdown 解析后的 Markdown 文件
+type importedMarkdown struct {
+	Type       string // post、page 或 moment，由 ExportContent 导出的文件带有该字段
+	Title      string
+	Slug       string
+	Date       *time.Time
+	Draft      bool
+	Status     string
+	Excerpt    string
+	CoverImage string
+	Categories []string
+	Tags       []string
+	Body       string
+
+	Visibility    string
+	CommentStatus string
+	Template      string
+	MenuOrder     int
+	Mood          string
+	Images        []string
+	SEO           models.SEOMeta
+	Translation   models.TranslationMeta
+}
+
+// ImportMarkdownDir 递归导入目录下带 YAML（---）或 TOML（+++）front matter 的 .md 文件。
+// slug 已被占用的文件会跳过，因此可以重复执行；缺失的分类与标签按名称自动创建。
+// ExportContent 导出的包解压后可直接导入，其中的页面、动态与 /uploads/ 文件按原样还原。
+func ImportMarkdownDir(opts MarkdownImportOptions) (*MarkdownImportReport, error) {
+	if opts.ImageDir == "" {
+		opts.ImageDir = media.ImageUploadDir
+	}
+	if !opts.DryRun {
+		if _, err := dao.GetUserByID(opts.AuthorID); err != nil {
+			return nil, fmt.Errorf("作者用户 %d 不存在: %w", opts.AuthorID, err)
+		}
+		if err := os.MkdirAll(opts.ImageDir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := listMarkdownFiles(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := newTaxonomyResolver(opts.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolver.loadExportManifest(filepath.Join(opts.Dir, "manifest.json")); err != nil {
+		return nil, err
+	}
+	images := &importImageCopier{opts: opts, copied: make(map[string]string)}
+
+	report := &MarkdownImportReport{DryRun: opts.DryRun, ScannedFiles: len(files)}
+	claimedSlugs := make(map[string]bool)
+	for _, file := range files {
+		item := importMarkdownFile(file, opts, resolver, images, claimedSlugs)
+		switch item.Action {
+		case ImportActionCreate:
+			report.Created++
+		case ImportActionSkip:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	report.CopiedImages = images.count
+	report.CreatedCategories = resolver.createdCategories
+	report.CreatedTags = resolver.createdTags
+	return report, nil
+}
+
+func importMarkdownFile(file string, opts MarkdownImportOptions, resolver *taxonomyResolver, images *importImageCopier, claimed map[string]bool) MarkdownImportItem {
+	item := MarkdownImportItem{File: file}
+	fail := func(err error) MarkdownImportItem {
+		return failImportItem(item, err)
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return fail(err)
+	}
+	relPath, err := filepath.Rel(opts.Dir, file)
+	if err != nil {
+		relPath = file
+	}
+	item.File = relPath
+	doc, err := parseMarkdownDocument(raw, relPath)
+	if err != nil {
+		return fail(err)
+	}
+	item.Type = doc.Type
+	item.Title = doc.Title
+	item.Slug = doc.Slug
+	item.Categories = doc.Categories
+	item.Tags = doc.Tags
+
+	switch doc.Type {
+	case PreviewTypePage:
+		return importPageDocument(item, doc, file, opts, images, claimed)
+	case PreviewTypeMoment:
+		return importMomentDocument(item, doc, file, opts, images)
+	}
+
+	if claimed[doc.Type+":"+doc.Slug] || postSlugTaken(doc.Slug, 0) {
+		item.Action = ImportActionSkip
+		item.Message = "slug 已存在"
+		return item
+	}
+
+	status := "published"
+	var publishAt *time.Time
+	switch {
+	case doc.Status == "pending":
+		status = "pending"
+	case doc.Draft:
+		status = "draft"
+	case doc.Date != nil && doc.Date.After(time.Now()):
+		status = StatusScheduled
+		publishAt = doc.Date
+	}
+	item.Status = status
+
+	// 本地图片复制到上传目录并改写为上传后的地址
+	body, cover, err := images.rewriteDocument(&item, doc, file)
+	if err != nil {
+		return fail(err)
+	}
+
+	categoryIDs, err := resolver.categoryIDs(doc.Categories)
+	if err != nil {
+		return fail(err)
+	}
+	tagIDs, err := resolver.tagIDs(doc.Tags)
+	if err != nil {
+		return fail(err)
+	}
+
+	claimed[doc.Type+":"+doc.Slug] = true
+	item.Action = ImportActionCreate
+	if opts.DryRun {
+		return item
+	}
+
+	post := &models.Post{
+		Title:           doc.Title,
+		Slug:            doc.Slug,
+		Content:         body,
+		Excerpt:         doc.Excerpt,
+		CoverImage:      cover,
+		AuthorID:        opts.AuthorID,
+		SEOMeta:         doc.SEO,
+		TranslationMeta: doc.Translation,
+	}
+	if doc.CommentStatus == "open" || doc.CommentStatus == "closed" {
+		post.CommentStatus = doc.CommentStatus
+	}
+	if err := NormalizeSEOMeta(&post.SEOMeta); err != nil {
+		return fail(err)
+	}
+	if err := ApplyPostVisibility(post, doc.Visibility, ""); err != nil {
+		return fail(err)
+	}
+	if err := ApplyPostStatus(post, status, publishAt); err != nil {
+		return fail(err)
+	}
+	// 保留原站的发布时间
+	if doc.Date != nil {
+		post.CreatedAt = *doc.Date
+		if status == "published" {
+			post.PublishedAt = doc.Date
+		}
+	}
+	if err := resolveImportTranslation(&models.Post{}, &post.TranslationMeta, &item); err != nil {
+		return fail(err)
+	}
+	if err := CreatePost(post, categoryIDs, tagIDs); err != nil {
+		return fail(err)
+	}
+	item.PostID = post.ID
+	return item
+}
+
+// importPageDocument 导入 type: page 的文件，slug 已存在时跳过
+func importPageDocument(item MarkdownImportItem, doc *importedMarkdown, file string, opts MarkdownImportOptions, images *importImageCopier, claimed map[string]bool) MarkdownImportItem {
+	if claimed[doc.Type+":"+doc.Slug] || dao.PageSlugExists(doc.Slug) {
+		item.Action = ImportActionSkip
+		item.Message = "slug 已存在"
+		return item
+	}
+	item.Status = "published"
+	if doc.Draft {
+		item.Status = "draft"
+	}
+
+	body, _, err := images.rewriteDocument(&item, doc, file)
+	if err != nil {
+		return failImportItem(item, err)
+	}
+	claimed[doc.Type+":"+doc.Slug] = true
+	item.Action = ImportActionCreate
+	if opts.DryRun {
+		return item
+	}
+
+	page := &models.Page{
+		Title:           doc.Title,
+		Slug:            doc.Slug,
+		Content:         body,
+		Excerpt:         doc.Excerpt,
+		Template:        doc.Template,
+		Status:          item.Status,
+		MenuOrder:       doc.MenuOrder,
+		SEOMeta:         doc.SEO,
+		TranslationMeta: doc.Translation,
+	}
+	if err := NormalizeSEOMeta(&page.SEOMeta); err != nil {
+		return failImportItem(item, err)
+	}
+	if err := resolveImportTranslation(&models.Page{}, &page.TranslationMeta, &item); err != nil {
+		return failImportItem(item, err)
+	}
+	if doc.Date != nil {
+		page.CreatedAt = *doc.Date
+	}
+	if err := CreatePage(page); err != nil {
+		return failImportItem(item, err)
+	}
+	item.PageID = page.ID
+	return item
+}
+
+// importMomentDocument 导入 type: moment 的文件；动态没有 slug，同一时间（精确到秒）已有动态时视为已导入
+func importMomentDocument(item MarkdownImportItem, doc *importedMarkdown, file string, opts MarkdownImportOptions, images *importImageCopier) MarkdownImportItem {
+	if doc.Date == nil {
+		return failImportItem(item, ErrImportNoDate)
+	}
+	if dao.MomentExistsAt(*doc.Date) {
+		item.Action = ImportActionSkip
+		item.Message = "同一时间的动态已存在"
+		return item
+	}
+	body, _, err := images.rewriteDocument(&item, doc, file)
+	if err != nil {
+		return failImportItem(item, err)
+	}
+
+	status := "published"
+	switch {
+	case doc.Draft:
+		status = "draft"
+	case doc.Date.After(time.Now()):
+		status = StatusScheduled
+	}
+	item.Status = status
+
+	moment := &models.Moment{
+		Content:   body,
+		Mood:      doc.Mood,
+		Status:    status,
+		CreatedAt: *doc.Date,
+	}
+	if status != "draft" {
+		moment.PublishedAt = doc.Date
+	}
+	for _, ref := range doc.Images {
+		url, ok, err := images.copyRef(ref, filepath.Dir(file), strings.TrimSuffix(file, filepath.Ext(file)))
+		if err != nil {
+			return failImportItem(item, err)
+		}
+		item.Images++
+		if !ok {
+			item.MissingImages = append(item.MissingImages, ref)
+			url = ref
+		}
+		moment.Images = append(moment.Images, url)
+	}
+
+	item.Action = ImportActionCreate
+	if opts.DryRun {
+		return item
+	}
+	if err := CreateMoment(moment); err != nil {
+		return failImportItem(item, err)
+	}
+	item.MomentID = moment.ID
+	return item
+}
+
+// importTranslationGroup Hugo 的 translationKey 可以是任意字符串，超出字段长度时取哈希
+func importTranslationGroup(key string) string {
+	key = strings.TrimSpace(key)
+	if len(key) <= 36 {
+		return key
+	}
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:])[:36]
+}
+
+// resolveImportTranslation 翻译组中已有同语言版本时不加入该组，作为独立内容导入
+func resolveImportTranslation(model interface{}, meta *models.TranslationMeta, item *MarkdownImportItem) error {
+	if meta.Lang == "" {
+		meta.Lang = DefaultLang()
+	}
+	if meta.TranslationGroup == "" {
+		return nil
+	}
+	taken, err := dao.TranslationLangTaken(model, meta.TranslationGroup, meta.Lang, 0)
+	if err != nil {
+		return err
+	}
+	if taken {
+		meta.TranslationGroup = ""
+		item.Message = ErrTranslationExists.Error() + "，已作为独立内容导入"
+	}
+	return nil
+}
+
+func failImportItem(item MarkdownImportItem, err error) MarkdownImportItem {
+	item.Action = ImportActionError
+	item.Message = err.Error()
+	return item
+}
+
+func listMarkdownFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// 跳过隐藏目录（.git 等）
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext == ".md" || ext == ".markdown" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// parseMarkdownDocument 解析 front matter 并映射到文章字段
+func parseMarkdownDocument(raw []byte, relPath string) (*importedMarkdown, error) {
+	meta, body, err := splitFrontMatter(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	rawType := strings.ToLower(frontMatterString(meta, "type"))
+	doc := &importedMarkdown{
+		Type:       rawType,
+		Title:      strings.TrimSpace(frontMatterString(meta, "title")),
+		Status:     strings.ToLower(frontMatterString(meta, "status")),
+		Excerpt:    strings.TrimSpace(frontMatterString(meta, "excerpt", "description", "summary")),
+		CoverImage: strings.TrimSpace(frontMatterString(meta, "cover", "cover_image", "image", "featured_image", "thumbnail")),
+		Categories: frontMatterList(meta, "categories", "category"),
+		Tags:       frontMatterList(meta, "tags", "tag", "keywords"),
+		Body:       strings.TrimSpace(body),
+
+		Visibility:    strings.ToLower(frontMatterString(meta, "visibility")),
+		CommentStatus: strings.ToLower(frontMatterString(meta, "comment_status")),
+		Template:      frontMatterString(meta, "template"),
+		Mood:          frontMatterString(meta, "mood"),
+		Images:        frontMatterList(meta, "images"),
+		SEO: models.SEOMeta{
+			MetaTitle:       frontMatterString(meta, "meta_title"),
+			MetaDescription: frontMatterString(meta, "meta_description"),
+			CanonicalURL:    frontMatterString(meta, "canonical_url"),
+			OGImage:         frontMatterString(meta, "og_image"),
+		},
+	}
+	// Hugo、Hexo 等的 type 字段另有含义，只识别本站导出的三种类型
+	if doc.Type != PreviewTypePage && doc.Type != PreviewTypeMoment {
+		doc.Type = PreviewTypePost
+	}
+	doc.SEO.NoIndex, _ = frontMatterBool(meta, "noindex")
+	// 语言代码无效时忽略，按站点默认语言导入
+	if lang, err := NormalizeLang(frontMatterString(meta, "lang", "language")); err == nil {
+		doc.Translation.Lang = lang
+	}
+	doc.Translation.TranslationGroup = importTranslationGroup(frontMatterString(meta, "translation_key", "translationKey"))
+	if n, err := strconv.Atoi(frontMatterString(meta, "menu_order")); err == nil {
+		doc.MenuOrder = n
+	}
+	if doc.Title == "" && doc.Type != PreviewTypeMoment {
+		return nil, ErrImportNoTitle
+	}
+
+	// Hugo 使用 draft: true，Hexo 使用 published: false
+	if draft, ok := frontMatterBool(meta, "draft"); ok {
+		doc.Draft = draft
+	} else if published, ok := frontMatterBool(meta, "published"); ok {
+		doc.Draft = !published
+	}
+
+	for _, key := range []string{"date", "publishDate", "published_at"} {
+		if t, ok := frontMatterTime(meta, key); ok {
+			doc.Date = &t
+			break
+		}
+	}
+
+	// slug 优先取 front matter，其次取文件名（去掉 Jekyll 风格的日期前缀）与标题；
+	// 都无法生成英文 slug 时（如纯中文）使用相对路径的哈希，保证重复导入时 slug 不变
+	name := strings.TrimSuffix(filepath.Base(relPath), filepath.Ext(relPath))
+	if strings.EqualFold(name, "index") {
+		// Hugo 的 page bundle：<slug>/index.md
+		name = filepath.Base(filepath.Dir(relPath))
+	}
+	doc.Slug = importSlug(frontMatterString(meta, "slug"), jekyllDatePrefix.ReplaceAllString(name, ""), doc.Title)
+	if slug := frontMatterString(meta, "slug"); slug != "" && isPreviewType(rawType) {
+		// 本站导出的文件原样保留 slug（可能包含中文），保证前台地址不变
+		doc.Slug = slug
+	}
+	switch {
+	case doc.Type == PreviewTypeMoment:
+		doc.Slug = ""
+	case doc.Slug == "":
+		sum := sha1.Sum([]byte(filepath.ToSlash(relPath)))
+		doc.Slug = "post-" + hex.EncodeToString(sum[:5])
+	}
+
+	// Hexo 以 <!-- more --> 分隔摘要
+	if loc := moreMarkerPattern.FindStringIndex(doc.Body); loc != nil {
+		if doc.Excerpt == "" {
+			doc.Excerpt = markdownToPlainText(doc.Body[:loc[0]])
+		}
+		doc.Body = strings.TrimSpace(doc.Body[:loc[0]]) + "\n\n" + strings.TrimSpace(doc.Body[loc[1]:])
+	}
+	return doc, nil
+}
+
+// splitFrontMatter 拆分 front matter 与正文，支持 YAML（---）与 TOML（+++），
+// 也兼容 Hexo 省略开头 --- 的写法
+func splitFrontMatter(raw []byte) (map[string]interface{}, string, error) {
+	text := strings.TrimPrefix(string(raw), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var delimiter string
+	switch {
+	case strings.HasPrefix(text, "---\n"):
+		delimiter = "---"
+		text = text[4:]
+	case strings.HasPrefix(text, "+++\n"):
+		delimiter = "+++"
+		text = text[4:]
+	default:
+		delimiter = "---"
+	}
+
+	end := strings.Index("\n"+text, "\n"+delimiter+"\n")
+	if end < 0 && strings.HasSuffix(text, "\n"+delimiter) {
+		end = len(text) - len(delimiter)
+	}
+	if end < 0 {
+		return nil, "", ErrImportNoFrontMatter
+	}
+	header := text[:end]
+	body := ""
+	if rest := end + len(delimiter) + 1; rest < len(text) {
+		body = text[rest:]
+	}
+
+	meta := make(map[string]interface{})
+	var err error
+	if delimiter == "+++" {
+		err = toml.Unmarshal([]byte(header), &meta)
+	} else {
+		err = decodeYAMLFrontMatter([]byte(header), meta)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("front matter 解析失败: %w", err)
+	}
+	if len(meta) == 0 {
+		return nil, "", ErrImportNoFrontMatter
+	}
+	return meta, body, nil
+}
+
+// decodeYAMLFrontMatter 时间戳保留原文，由 frontMatterTime 按本地时区解析
+// （yaml.v3 会把不带时区的时间当作 UTC）
+func decodeYAMLFrontMatter(header []byte, meta map[string]interface{}) error {
+	var nodes map[string]yaml.Node
+	if err := yaml.Unmarshal(header, &nodes); err != nil {
+		return err
+	}
+	for key, node := range nodes {
+		if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+			meta[key] = node.Value
+			continue
+		}
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		meta[key] = value
+	}
+	return nil
+}
+
+func frontMatterString(meta map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := meta[key]; ok && v != nil {
+			if s := strings.TrimSpace(fmt.Sprint(v)); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func frontMatterBool(meta map[string]interface{}, key string) (bool, bool) {
+	switch v := meta[key].(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes":
+			return true, true
+		case "false", "no":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func frontMatterTime(meta map[string]interface{}, key string) (time.Time, bool) {
+	switch v := meta[key].(type) {
+	case nil:
+		return time.Time{}, false
+	case time.Time:
+		return v, true
+	default:
+		// TOML 的本地日期时间类型与字符串统一按文本解析
+		s := strings.TrimSpace(fmt.Sprint(v))
+		for _, layout := range frontMatterDateLayouts {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// frontMatterList 读取字符串或列表；Hexo 的层级分类（嵌套列表）会被展开
+func frontMatterList(meta map[string]interface{}, keys ...string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	var collect func(v interface{})
+	collect = func(v interface{}) {
+		switch val := v.(type) {
+		case nil:
+		case []interface{}:
+			for _, elem := range val {
+				collect(elem)
+			}
+		case string:
+			for _, part := range strings.Split(val, ",") {
+				if part = strings.TrimSpace(part); part != "" && !seen[strings.ToLower(part)] {
+					seen[strings.ToLower(part)] = true
+					result = append(result, part)
+				}
+			}
+		default:
+			collect(fmt.Sprint(val))
+		}
+	}
+	for _, key := range keys {
+		if v, ok := meta[key]; ok {
+			collect(v)
+			break
+		}
+	}
+	return result
+}
+
+// importSlug 返回第一个能生成英文 slug 的候选值
+func importSlug(candidates ...string) string {
+	for _, candidate := range candidates {
+		if slug := strings.Trim(importSlugPattern.ReplaceAllString(strings.ToLower(candidate), "-"), "-"); slug != "" {
+			return slug
+		}
+	}
+	return ""
+}
+
+// taxonomySlug 生成分类、标签的 slug，保留中文等非 ASCII 字母
+func taxonomySlug(name string) string {
+	var b strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			lastDash = false
+		} else if !lastDash && b.Len() > 0 {
+			b.WriteByte('-')
+			lastDash = true
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// taxonomyResolver 按名称或 slug 匹配已有分类、标签，缺失时自动创建
+type taxonomyResolver struct {
+	dryRun            bool
+	categories        map[string]uint64 // 小写名称或 slug -> ID
+	tags              map[string]uint64
+	categorySlugs     map[string]bool
+	tagSlugs          map[string]bool
+	categoryHints     map[string]exportTaxonomy // 导出包 manifest.json 中的原 slug 与描述
+	tagHints          map[string]exportTaxonomy
+	createdCategories []string
+	createdTags       []string
+}
+
+func newTaxonomyResolver(dryRun bool) (*taxonomyResolver, error) {
+	r := &taxonomyResolver{
+		dryRun:        dryRun,
+		categories:    make(map[string]uint64),
+		tags:          make(map[string]uint64),
+		categorySlugs: make(map[string]bool),
+		tagSlugs:      make(map[string]bool),
+		categoryHints: make(map[string]exportTaxonomy),
+		tagHints:      make(map[string]exportTaxonomy),
+	}
+	categories, err := ListCategories()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range categories {
+		r.categories[strings.ToLower(c.Name)] = c.ID
+		r.categories[strings.ToLower(c.Slug)] = c.ID
+		r.categorySlugs[c.Slug] = true
+	}
+	tags, err := ListTags()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		r.tags[strings.ToLower(t.Name)] = t.ID
+		r.tags[strings.ToLower(t.Slug)] = t.ID
+		r.tagSlugs[t.Slug] = true
+	}
+	return r, nil
+}
+
+// loadExportManifest 读取 ExportContent 生成的 manifest.json，新建分类、标签时沿用原 slug 与描述；
+// 文件不存在时忽略
+func (r *taxonomyResolver) loadExportManifest(file string) error {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var manifest contentExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("manifest.json 解析失败: %w", err)
+	}
+	for _, c := range manifest.Categories {
+		r.categoryHints[strings.ToLower(c.Name)] = c
+	}
+	for _, t := range manifest.Tags {
+		r.tagHints[strings.ToLower(t.Name)] = t
+	}
+	return nil
+}
+
+func (r *taxonomyResolver) categoryIDs(names []string) ([]uint64, error) {
+	return r.resolve(names, r.categories, r.categorySlugs, r.categoryHints, &r.createdCategories, func(name, slug string) (uint64, error) {
+		c := &models.Category{Name: name, Slug: slug, Description: r.categoryHints[strings.ToLower(name)].Description}
+		if err := CreateCategory(c); err != nil {
+			return 0, fmt.Errorf("创建分类 %s 失败: %w", name, err)
+		}
+		return c.ID, nil
+	})
+}
+
+func (r *taxonomyResolver) tagIDs(names []string) ([]uint64, error) {
+	return r.resolve(names, r.tags, r.tagSlugs, r.tagHints, &r.createdTags, func(name, slug string) (uint64, error) {
+		t := &models.Tag{Name: name, Slug: slug, Description: r.tagHints[strings.ToLower(name)].Description}
+		if err := CreateTag(t); err != nil {
+			return 0, fmt.Errorf("创建标签 %s 失败: %w", name, err)
+		}
+		return t.ID, nil
+	})
+}
+
+func (r *taxonomyResolver) resolve(names []string, known map[string]uint64, slugs map[string]bool, hints map[string]exportTaxonomy, created *[]string, create func(name, slug string) (uint64, error)) ([]uint64, error) {
+	ids := make([]uint64, 0, len(names))
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if id, ok := known[key]; ok {
+			if id != 0 {
+				ids = append(ids, id)
+			}
+			continue
+		}
+
+		base := taxonomySlug(name)
+		if hint := hints[key]; hint.Slug != "" {
+			base = hint.Slug
+		}
+		if base == "" {
+			continue
+		}
+		slug := base
+		for i := 1; slugs[slug]; i++ {
+			slug = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		// 试运行时记为 0，只用于报告
+		var id uint64
+		if !r.dryRun {
+			var err error
+			if id, err = create(name, slug); err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		known[key] = id
+		slugs[slug] = true
+		*created = append(*created, name)
+	}
+	return ids, nil
+}
+
+// importImageCopier 复制 Markdown 中引用的本地图片，同一源文件只复制一次
+type importImageCopier struct {
+	opts   MarkdownImportOptions
+	copied map[string]string // 源文件绝对路径 -> 上传后的 URL
+	count  int
+}
+
+// rewriteDocument 处理正文与封面、分享图片中的本地引用，结果记录到 item
+func (c *importImageCopier) rewriteDocument(item *MarkdownImportItem, doc *importedMarkdown, file string) (string, string, error) {
+	baseDir, assetDir := filepath.Dir(file), strings.TrimSuffix(file, filepath.Ext(file))
+	body, refs, missing, err := c.rewrite(doc.Body, baseDir, assetDir)
+	if err != nil {
+		return "", "", err
+	}
+	item.Images = refs
+	item.MissingImages = missing
+
+	cover := doc.CoverImage
+	for _, ref := range []*string{&cover, &doc.SEO.OGImage} {
+		if *ref == "" {
+			continue
+		}
+		rewritten, ok, err := c.copyRef(*ref, baseDir, assetDir)
+		if err != nil {
+			return "", "", err
+		}
+		if ok {
+			*ref = rewritten
+		}
+	}
+	return body, cover, nil
+}
+
+// rewrite 改写正文中的本地图片引用，返回新正文、本地图片数与缺失的图片；
+// 指向 /uploads/ 的普通链接（附件）也一并还原
+func (c *importImageCopier) rewrite(body, baseDir, assetDir string) (string, int, []string, error) {
+	refs := 0
+	var missing []string
+	var copyErr error
+
+	replace := func(pattern *regexp.Regexp, countRefs bool) {
+		body = pattern.ReplaceAllStringFunc(body, func(match string) string {
+			parts := pattern.FindStringSubmatch(match)
+			if copyErr != nil || !isLocalImageRef(parts[2]) {
+				return match
+			}
+			if countRefs {
+				refs++
+			}
+			url, ok, err := c.copyRef(parts[2], baseDir, assetDir)
+			if err != nil {
+				copyErr = err
+				return match
+			}
+			if !ok {
+				missing = append(missing, parts[2])
+				return match
+			}
+			return parts[1] + url + parts[3]
+		})
+	}
+	replace(mdImageRefPattern, true)
+	replace(htmlImageRefPattern, true)
+	replace(mdUploadLinkPattern, false)
+	replace(htmlUploadLinkPattern, false)
+	// 缺失的 /uploads/ 图片会再次被链接规则匹配
+	missing = dedupeStrings(missing)
+	return body, refs, missing, copyErr
+}
+
+// copyRef 定位并复制单个本地图片；找不到文件时返回 ok=false
+func (c *importImageCopier) copyRef(ref, baseDir, assetDir string) (string, bool, error) {
+	if !isLocalImageRef(ref) {
+		return ref, true, nil
+	}
+	if strings.HasPrefix(ref, "/uploads/") {
+		return c.restoreUpload(ref, baseDir, assetDir)
+	}
+	src := c.locate(ref, baseDir, assetDir)
+	if src == "" {
+		return "", false, nil
+	}
+	if url, ok := c.copied[src]; ok {
+		return url, true, nil
+	}
+
+	filename := media.GenerateFileName(filepath.Base(src))
+	dst := filepath.Join(c.opts.ImageDir, filename)
+	if !c.opts.DryRun {
+		if err := copyFile(src, dst); err != nil {
+			return "", false, fmt.Errorf("复制图片 %s 失败: %w", ref, err)
+		}
+	}
+	url := media.GetFullFileURL(filepath.ToSlash(strings.TrimPrefix(dst, "./")))
+	c.copied[src] = url
+	c.count++
+	return url, true, nil
+}
+
+// restoreUpload 处理 ExportContent 导出的 /uploads/ 站内路径：按原路径还原到上传目录，
+// 本地已存在同名文件时直接引用
+func (c *importImageCopier) restoreUpload(ref, baseDir, assetDir string) (string, bool, error) {
+	clean := path.Clean(strings.SplitN(strings.SplitN(ref, "?", 2)[0], "#", 2)[0])
+	if !strings.HasPrefix(clean, "/uploads/") {
+		return "", false, nil
+	}
+	url := media.GetFullFileURL(clean)
+	dst := filepath.Join(media.UploadDir, filepath.FromSlash(strings.TrimPrefix(clean, "/uploads/")))
+	if _, err := os.Stat(dst); err == nil {
+		return url, true, nil
+	}
+
+	src := c.locate(clean, baseDir, assetDir)
+	if src == "" {
+		return "", false, nil
+	}
+	if _, ok := c.copied[src]; ok {
+		return url, true, nil
+	}
+	if !c.opts.DryRun {
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return "", false, err
+		}
+		if err := copyFile(src, dst); err != nil {
+			return "", false, fmt.Errorf("复制文件 %s 失败: %w", ref, err)
+		}
+	}
+	c.copied[src] = url
+	c.count++
+	return url, true, nil
+}
+
+// locate 依次在文章所在目录、Hexo 资源目录（与文章同名的目录）和静态目录中查找图片
+func (c *importImageCopier) locate(ref, baseDir, assetDir string) string {
+	ref = strings.SplitN(strings.SplitN(ref, "?", 2)[0], "#", 2)[0]
+	var candidates []string
+	if strings.HasPrefix(ref, "/") {
+		root := c.opts.StaticDir
+		if root == "" {
+			root = c.opts.Dir
+		}
+		candidates = append(candidates, filepath.Join(root, filepath.FromSlash(ref)))
+	} else {
+		candidates = append(candidates,
+			filepath.Join(baseDir, filepath.FromSlash(ref)),
+			filepath.Join(assetDir, filepath.FromSlash(ref)),
+		)
+	}
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			if abs, err := filepath.Abs(path); err == nil {
+				return abs
+			}
+			return path
+		}
+	}
+	return ""
+}
+
+func isLocalImageRef(ref string) bool {
+	lower := strings.ToLower(ref)
+	return ref != "" &&
+		!strings.HasPrefix(lower, "http://") &&
+		!strings.HasPrefix(lower, "https://") &&
+		!strings.HasPrefix(lower, "//") &&
+		!strings.HasPrefix(lower, "data:")
+}
+
+// dedupeStrings 去重并保持原有顺序
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
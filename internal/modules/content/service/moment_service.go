@@ -7,9 +7,8 @@ import (
 )
 
 func CreateMoment(moment *models.Moment) error {
-	if moment.Status == "published" && moment.PublishedAt == nil {
-		now := time.Now()
-		moment.PublishedAt = &now
+	if err := normalizeMomentPublishTime(moment); err != nil {
+		return err
 	}
 	return dao.CreateMoment(moment)
 }
@@ -19,17 +18,32 @@ func GetMomentByID(id uint64) (*models.Moment, error) {
 }
 
 func UpdateMoment(moment *models.Moment) error {
-	if moment.Status == "published" && moment.PublishedAt == nil {
-		now := time.Now()
-		moment.PublishedAt = &now
+	if err := normalizeMomentPublishTime(moment); err != nil {
+		return err
 	}
-	if moment.Status != "published" {
+	return dao.UpdateMoment(moment)
+}
+
+// normalizeMomentPublishTime 按状态维护发布时间：定时动态必须有未来的计划时间，草稿清空发布时间
+func normalizeMomentPublishTime(moment *models.Moment) error {
+	switch moment.Status {
+	case "published":
+		if moment.PublishedAt == nil {
+			now := time.Now()
+			moment.PublishedAt = &now
+		}
+	case StatusScheduled:
+		if moment.PublishedAt == nil || !moment.PublishedAt.After(time.Now()) {
+			return ErrInvalidSchedule
+		}
+	default:
 		moment.PublishedAt = nil
 	}
-	return dao.UpdateMoment(moment)
+	return nil
 }
 
 func DeleteMoment(id uint64) error {
+	dao.DeletePreviewKey(PreviewTypeMoment, id)
 	return dao.DeleteMoment(id)
 }
 
@@ -5,23 +5,56 @@ import (
 	"api/internal/modules/content/models"
 )
 
-func CreatePage(page *models.Page) error {
-	return dao.CreatePage(page)
+func CreatePage(page *models.Page, writes ...ContentWrite) error {
+	if page.Lang == "" {
+		page.Lang = DefaultLang()
+	}
+	err := dao.RunContentTx(func(t *dao.ContentTx) error {
+		if err := runContentWrites(t, writes); err != nil {
+			return err
+		}
+		return t.CreatePage(page)
+	})
+	if err != nil {
+		return err
+	}
+	InvalidateSitemap()
+	return nil
 }
 func GetPageByID(id uint64) (*models.Page, error) {
 	return dao.GetPageByID(id)
 }
 
-// GetPageBySlug 通过slug获取已发布的页面
+// GetPageBySlug 通过slug获取页面，未发布的页面需再经 CheckPageAccess 判断
 func GetPageBySlug(slug string) (*models.Page, error) {
 	return dao.GetPageBySlug(slug)
 }
 func ListPages() ([]models.Page, error) {
 	return dao.ListPages()
 }
-func UpdatePage(page *models.Page) error {
-	return dao.UpdatePage(page)
+
+// ListPublishedPages 公开列表只返回已发布的页面
+func ListPublishedPages() ([]models.Page, error) {
+	return dao.ListPublishedPages()
+}
+func UpdatePage(page *models.Page, writes ...ContentWrite) error {
+	err := dao.RunContentTx(func(t *dao.ContentTx) error {
+		if err := runContentWrites(t, writes); err != nil {
+			return err
+		}
+		return t.UpdatePage(page)
+	})
+	if err != nil {
+		return err
+	}
+	InvalidateSitemap()
+	return nil
 }
 func DeletePage(id uint64) error {
-	return dao.DeletePage(id)
+	dao.DeletePreviewKey(PreviewTypePage, id)
+	if err := dao.DeletePage(id); err != nil {
+		return err
+	}
+	InvalidateSitemap()
+	return nil
 }
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAutosaveTTL      = 7 * 24 * time.Hour
+	autosaveCleanupInterval = time.Hour // 过期记录的清理间隔，读取时已按过期时间过滤，无需每次保存都清理
+)
+
+var ErrAutosaveNotFound = errors.New("没有自动保存的草稿")
+
+// autosaveCleanupAt 上次清理过期自动保存的时间（UnixNano）
+var autosaveCleanupAt atomic.Int64
+
+// AutosaveInput 编辑器提交的自动保存内容
+type AutosaveInput struct {
+	Title       string
+	Excerpt     string
+	Content     string
+	CoverImage  string
+	BaseVersion uint64 // 开始编辑时的文章版本号
+}
+
+// AutosaveComparison 自动保存内容与文章已保存内容的对比
+type AutosaveComparison struct {
+	SavedVersion      uint64     `json:"saved_version"`
+	Stale             bool       `json:"stale"` // 自动保存之后文章已被保存过（版本号变化），直接恢复会覆盖他人的修改
+	TitleChanged      bool       `json:"title_changed"`
+	ExcerptChanged    bool       `json:"excerpt_changed"`
+	CoverImageChanged bool       `json:"cover_image_changed"`
+	ContentChanged    bool       `json:"content_changed"`
+	Added             int        `json:"added"`
+	Removed           int        `json:"removed"`
+	Lines             []DiffLine `json:"lines"` // 正文行级差异：已保存内容 -> 自动保存内容
+}
+
+// SavePostAutosave 保存用户在某篇文章（postID 为 0 表示新建文章）上的自动保存内容，并按间隔顺带清理过期记录
+func SavePostAutosave(userID, postID uint64, input AutosaveInput) (*models.PostAutosave, error) {
+	ttl := config.Load().AutosaveTTL
+	if ttl <= 0 {
+		ttl = defaultAutosaveTTL
+	}
+	now := time.Now()
+	autosave := &models.PostAutosave{
+		UserID:      userID,
+		PostID:      postID,
+		Title:       input.Title,
+		Excerpt:     input.Excerpt,
+		Content:     input.Content,
+		CoverImage:  input.CoverImage,
+		BaseVersion: input.BaseVersion,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+	if err := dao.UpsertPostAutosave(autosave); err != nil {
+		return nil, err
+	}
+	cleanupExpiredAutosaves(now)
+	return autosave, nil
+}
+
+// cleanupExpiredAutosaves 每个实例在 autosaveCleanupInterval 内最多清理一次过期记录，避免编辑器频繁自动保存时反复全表删除
+func cleanupExpiredAutosaves(now time.Time) {
+	last := autosaveCleanupAt.Load()
+	if now.UnixNano()-last < int64(autosaveCleanupInterval) || !autosaveCleanupAt.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	if _, err := dao.DeleteExpiredPostAutosaves(now); err != nil {
+		fmt.Printf("[autosave] delete expired error: %v\n", err)
+	}
+}
+
+// GetPostAutosave 获取用户最近一次自动保存的内容，不存在或已过期时返回 ErrAutosaveNotFound
+func GetPostAutosave(userID, postID uint64) (*models.PostAutosave, error) {
+	autosave, err := dao.GetPostAutosave(userID, postID, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAutosaveNotFound
+	}
+	return autosave, err
+}
+
+// DiscardPostAutosave 丢弃自动保存的内容；文章正式保存后也会调用
+func DiscardPostAutosave(userID, postID uint64) error {
+	_, err := dao.DeletePostAutosave(userID, postID)
+	return err
+}
+
+// ComparePostAutosave 对比自动保存内容与文章当前已保存的内容
+func ComparePostAutosave(autosave *models.PostAutosave, post *models.Post) *AutosaveComparison {
+	lines := DiffTextLines(post.Content, autosave.Content)
+	result := &AutosaveComparison{
+		SavedVersion:      post.Version,
+		Stale:             autosave.BaseVersion != 0 && autosave.BaseVersion != post.Version,
+		TitleChanged:      autosave.Title != post.Title,
+		ExcerptChanged:    autosave.Excerpt != post.Excerpt,
+		CoverImageChanged: autosave.CoverImage != post.CoverImage,
+		ContentChanged:    autosave.Content != post.Content,
+		Lines:             lines,
+	}
+	for _, line := range lines {
+		switch line.Op {
+		case DiffInsert:
+			result.Added++
+		case DiffDelete:
+			result.Removed++
+		}
+	}
+	return result
+}
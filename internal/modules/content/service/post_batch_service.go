@@ -0,0 +1,200 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 批量操作类型
+const (
+	PostBatchStatus           = "status"
+	PostBatchTrash            = "trash"
+	PostBatchAddTags          = "add_tags"
+	PostBatchRemoveTags       = "remove_tags"
+	PostBatchAddCategories    = "add_categories"
+	PostBatchRemoveCategories = "remove_categories"
+	PostBatchAuthor           = "author"
+
+	maxPostBatchSize = 200
+)
+
+var (
+	ErrInvalidPostBatch  = errors.New("无效的批量操作")
+	ErrPostBatchTooLarge = fmt.Errorf("单次最多操作 %d 篇文章", maxPostBatchSize)
+	errPostBatchNotOwner = errors.New("只能编辑自己的文章")
+)
+
+var postBatchStatuses = map[string]bool{
+	"published":     true,
+	"draft":         true,
+	"pending":       true,
+	StatusScheduled: true,
+}
+
+// PostBatchRequest 批量操作参数，按 Action 使用对应字段
+type PostBatchRequest struct {
+	IDs         []uint64
+	Action      string
+	Status      string     // status
+	PublishAt   *time.Time // status=scheduled 时的计划时间
+	CategoryIDs []uint64   // add_categories/remove_categories
+	TagIDs      []uint64   // add_tags/remove_tags
+	AuthorID    uint64     // author
+}
+
+// PostBatchResult 单篇文章的处理结果
+type PostBatchResult struct {
+	ID      uint64 `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PostBatchReport 批量操作报告，Results 与请求中的 IDs 顺序一致
+type PostBatchReport struct {
+	Action    string            `json:"action"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []PostBatchResult `json:"results"`
+}
+
+// BatchUpdatePosts 在一个事务中批量修改文章；单篇失败不影响其他文章，失败原因记录在报告中。
+// 作者角色只能操作自己的文章，且不能修改作者
+func BatchUpdatePosts(req PostBatchRequest, editorID uint64, editorRole string) (*PostBatchReport, error) {
+	ids := dedupeIDs(req.IDs)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: ids 不能为空", ErrInvalidPostBatch)
+	}
+	if len(ids) > maxPostBatchSize {
+		return nil, ErrPostBatchTooLarge
+	}
+	apply, err := buildPostBatchAction(req, editorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := dao.RunPostBatch(ids, func(b *dao.PostBatchTx, post *models.Post) error {
+		if !CanEditPost(post, editorID, editorRole) {
+			return errPostBatchNotOwner
+		}
+		return apply(b, post)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PostBatchReport{Action: req.Action, Total: len(ids), Results: make([]PostBatchResult, 0, len(ids))}
+	changed := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		result := PostBatchResult{ID: id, Success: true}
+		if err := failures[id]; err != nil {
+			result.Success = false
+			result.Error = err.Error()
+			report.Failed++
+		} else {
+			report.Succeeded++
+			changed = append(changed, id)
+		}
+		report.Results = append(report.Results, result)
+	}
+	if report.Succeeded > 0 {
+		notifyPostsChanged(changed...)
+	}
+	return report, nil
+}
+
+// buildPostBatchAction 校验参数并返回对单篇文章执行的操作
+func buildPostBatchAction(req PostBatchRequest, editorRole string) (func(b *dao.PostBatchTx, post *models.Post) error, error) {
+	switch req.Action {
+	case PostBatchStatus, PostBatchTrash:
+		status := req.Status
+		if req.Action == PostBatchTrash {
+			status = StatusTrash
+		} else if !postBatchStatuses[status] {
+			return nil, fmt.Errorf("%w: 无效的状态值", ErrInvalidPostBatch)
+		}
+		return func(b *dao.PostBatchTx, post *models.Post) error {
+			if post.Status == status {
+				return nil
+			}
+			if err := ApplyPostStatus(post, status, req.PublishAt); err != nil {
+				return err
+			}
+			return b.SavePost(post)
+		}, nil
+
+	case PostBatchAddCategories, PostBatchRemoveCategories:
+		categoryIDs := dedupeIDs(req.CategoryIDs)
+		if len(categoryIDs) == 0 {
+			return nil, fmt.Errorf("%w: category_ids 不能为空", ErrInvalidPostBatch)
+		}
+		if req.Action == PostBatchRemoveCategories {
+			return func(b *dao.PostBatchTx, post *models.Post) error {
+				return b.RemoveCategories(post.ID, categoryIDs)
+			}, nil
+		}
+		categories, err := dao.GetCategoriesByIDs(categoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(categories) != len(categoryIDs) {
+			return nil, fmt.Errorf("%w: 分类不存在", ErrInvalidPostBatch)
+		}
+		return func(b *dao.PostBatchTx, post *models.Post) error {
+			return b.AddCategories(post.ID, categoryIDs)
+		}, nil
+
+	case PostBatchAddTags, PostBatchRemoveTags:
+		tagIDs := dedupeIDs(req.TagIDs)
+		if len(tagIDs) == 0 {
+			return nil, fmt.Errorf("%w: tag_ids 不能为空", ErrInvalidPostBatch)
+		}
+		if req.Action == PostBatchRemoveTags {
+			return func(b *dao.PostBatchTx, post *models.Post) error {
+				return b.RemoveTags(post.ID, tagIDs)
+			}, nil
+		}
+		tags, err := dao.GetTagsByIDs(tagIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) != len(tagIDs) {
+			return nil, fmt.Errorf("%w: 标签不存在", ErrInvalidPostBatch)
+		}
+		return func(b *dao.PostBatchTx, post *models.Post) error {
+			return b.AddTags(post.ID, tagIDs)
+		}, nil
+
+	case PostBatchAuthor:
+		if editorRole != "admin" {
+			return nil, fmt.Errorf("%w: 只有管理员可以修改作者", ErrInvalidPostBatch)
+		}
+		author, err := dao.GetUserByID(req.AuthorID)
+		if err != nil || (author.Role != "admin" && author.Role != "author") {
+			return nil, fmt.Errorf("%w: 作者不存在或没有写作权限", ErrInvalidPostBatch)
+		}
+		return func(b *dao.PostBatchTx, post *models.Post) error {
+			post.AuthorID = author.ID
+			return b.SavePost(post)
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: 不支持的操作 %q", ErrInvalidPostBatch, req.Action)
+	}
+}
+
+func dedupeIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	result := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
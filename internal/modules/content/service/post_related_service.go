@@ -0,0 +1,713 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/platform/redisstore"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	relatedTagWeight      = 3.0 // 每个共同标签
+	relatedCategoryWeight = 2.0 // 每个共同分类
+	relatedTextWeight     = 5.0 // 关键词 Jaccard 相似度（0~1）
+	relatedRecencyWeight  = 1.0 // 新近度（0~1，按半衰期衰减）
+	relatedRecencyHalf    = 180 * 24 * time.Hour
+
+	relatedCacheSize      = 20 // 每篇文章缓存的候选数量，接口 limit 不超过该值
+	relatedCacheTTL       = 24 * time.Hour
+	relatedGenerationKey  = "posts:related:generation"
+	relatedWarmedKey      = "posts:related:warmed" // 最近一次完成整体预计算的版本
+	relatedDirtyKey       = "posts:related:dirty"  // 待重新计算的文章 ID
+	relatedCacheKeyPrefix = "posts:related:"
+	relatedWorkerLockKey  = "content:related_posts:lock"
+	relatedDirtyBatch     = 500
+	relatedLocalCacheSize = 1024        // 进程内缓存最多保留的文章数
+	relatedCorpusReuse    = time.Minute // 请求路径上构建的候选集在该时间内复用
+
+	defaultRelatedWarmInterval = 5 * time.Minute
+)
+
+// RelatedScore 预计算的相关度
+type RelatedScore struct {
+	PostID           uint64  `json:"post_id"`
+	Score            float64 `json:"score"`
+	SharedTags       int     `json:"shared_tags"`
+	SharedCategories int     `json:"shared_categories"`
+	TextSimilarity   float64 `json:"text_similarity"`
+}
+
+// RelatedPost 相关文章接口返回项
+type RelatedPost struct {
+	ID               uint64     `json:"id"`
+	Title            string     `json:"title"`
+	Slug             string     `json:"slug"`
+	Excerpt          string     `json:"excerpt"`
+	CoverImage       string     `json:"cover_image"`
+	PublishedAt      *time.Time `json:"published_at"`
+	Score            float64    `json:"score"`
+	SharedTags       int        `json:"shared_tags"`
+	SharedCategories int        `json:"shared_categories"`
+}
+
+// 未启用 Redis 时使用进程内缓存
+var (
+	localRelatedGeneration atomic.Int64
+	localRelatedMu         sync.Mutex
+	localRelatedCache      = make(map[uint64]*localRelatedEntry)
+	localRelatedWarmed     int64
+	localRelatedWarmedOK   bool
+	localRelatedDirty      = make(map[uint64]struct{})
+
+	relatedWorkerOnce    sync.Once
+	relatedWorkerRunning atomic.Bool // 预计算任务未启动时只能整体失效，也不能返回旧版本结果
+
+	relatedCorpusMu     sync.Mutex
+	relatedCorpusFlight *relatedCorpusCall
+)
+
+// localRelatedEntry 进程内缓存项；版本落后的条目在重新计算前作为旧结果返回
+type localRelatedEntry struct {
+	generation int64
+	lastUsed   time.Time
+	scores     []RelatedScore
+}
+
+// relatedCorpusCall 请求路径上的一次候选集构建，同一版本的并发未命中共用结果
+type relatedCorpusCall struct {
+	generation int64
+	done       chan struct{}
+	builtAt    time.Time
+	corpus     *relatedCorpus
+	err        error
+}
+
+type relatedDoc struct {
+	id          uint64
+	publishedAt time.Time
+	tags        map[uint64]struct{}
+	categories  map[uint64]struct{}
+	keywords    map[string]struct{}
+}
+
+// relatedCorpus 一次计算所需的全部候选文章特征
+type relatedCorpus struct {
+	docs  map[uint64]*relatedDoc
+	order []uint64
+}
+
+// GetRelatedPosts 返回与文章最相关的已发布文章，优先读取预计算缓存
+func GetRelatedPosts(postID uint64, limit int) ([]RelatedPost, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+	if limit > relatedCacheSize {
+		limit = relatedCacheSize
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	generation := relatedGeneration(ctx)
+	scores, ok := loadRelatedCache(ctx, generation, postID)
+	if !ok && relatedWorkerRunning.Load() {
+		// 版本刚递增、后台尚未重新预计算时先返回上一版本的结果，避免所有请求同时全量计算
+		scores, ok = loadStaleRelatedCache(ctx, postID)
+	}
+	if !ok {
+		corpus, err := sharedRelatedCorpus(generation)
+		if err != nil {
+			return nil, err
+		}
+		scores = corpus.score(postID, time.Now())
+		storeRelatedCache(ctx, generation, postID, scores)
+	}
+
+	if len(scores) > limit {
+		scores = scores[:limit]
+	}
+	ids := make([]uint64, 0, len(scores))
+	for _, s := range scores {
+		ids = append(ids, s.PostID)
+	}
+	posts, err := dao.ListPostsByOrderedIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreMap := make(map[uint64]RelatedScore, len(scores))
+	for _, s := range scores {
+		scoreMap[s.PostID] = s
+	}
+	result := make([]RelatedPost, 0, len(posts))
+	for _, post := range posts {
+		// 缓存期间文章可能已下线或改为私密
+		if post.Status != "published" || post.Visibility == VisibilityPrivate {
+			continue
+		}
+		excerpt := post.Excerpt
+		if post.Visibility == VisibilityPassword {
+			excerpt = ""
+		}
+		s := scoreMap[post.ID]
+		result = append(result, RelatedPost{
+			ID:               post.ID,
+			Title:            post.Title,
+			Slug:             post.Slug,
+			Excerpt:          excerpt,
+			CoverImage:       post.CoverImage,
+			PublishedAt:      post.PublishedAt,
+			Score:            s.Score,
+			SharedTags:       s.SharedTags,
+			SharedCategories: s.SharedCategories,
+		})
+	}
+	return result, nil
+}
+
+// InvalidateRelatedPosts 使全部相关文章缓存失效（分类、标签及其关键词变化时调用）。
+// 缓存键带版本号，递增版本即可整体失效；后台任务重新预计算完成前，读取时返回上一版本的结果。
+func InvalidateRelatedPosts() {
+	localRelatedGeneration.Add(1)
+
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Incr(ctx, relatedGenerationKey).Err(); err != nil {
+		fmt.Printf("[related] redis incr generation error: %v\n", err)
+	}
+}
+
+// MarkRelatedPostsDirty 文章内容、状态或分类标签变化时只标记这些文章，
+// 由后台任务重新计算它们以及与其共享分类、标签的文章，其余文章的缓存保持不变
+func MarkRelatedPostsDirty(postIDs []uint64) {
+	if len(postIDs) == 0 {
+		return
+	}
+	if !relatedWorkerRunning.Load() {
+		InvalidateRelatedPosts()
+		return
+	}
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		localRelatedMu.Lock()
+		defer localRelatedMu.Unlock()
+		for _, id := range postIDs {
+			localRelatedDirty[id] = struct{}{}
+		}
+		return
+	}
+	members := make([]interface{}, 0, len(postIDs))
+	for _, id := range postIDs {
+		members = append(members, strconv.FormatUint(id, 10))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.SAdd(ctx, relatedDirtyKey, members...).Err(); err != nil {
+		fmt.Printf("[related] redis mark dirty error: %v\n", err)
+	}
+}
+
+// StartRelatedPostsWorker 启动相关文章预计算任务：缓存版本变化后为所有已发布文章重新计算，
+// 否则只重新计算被标记的文章；多实例部署时同一周期只有一个实例执行
+func StartRelatedPostsWorker(interval time.Duration) {
+	relatedWorkerOnce.Do(func() {
+		if interval <= 0 {
+			interval = defaultRelatedWarmInterval
+		}
+		relatedWorkerRunning.Store(true)
+		go runRelatedPostsWorker(interval)
+	})
+}
+
+func runRelatedPostsWorker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := runWithInstanceLock(relatedWorkerLockKey, interval, warmRelatedPosts); err != nil {
+			fmt.Printf("[related] precompute error: %v\n", err)
+		}
+		<-ticker.C
+	}
+}
+
+// warmRelatedPosts 当前版本尚未整体预计算时为所有文章计算，否则只处理被标记的文章
+func warmRelatedPosts() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	generation := relatedGeneration(ctx)
+	warmed, ok := relatedWarmedGeneration(ctx)
+	cancel()
+
+	if !ok || warmed != generation {
+		// 整体预计算覆盖全部文章，之前的标记一并清除
+		drainRelatedDirty()
+		count, err := PrecomputeRelatedPosts(generation)
+		if err != nil {
+			return err
+		}
+		setRelatedWarmedGeneration(generation)
+		fmt.Printf("[related] precomputed related posts for %d posts\n", count)
+		return nil
+	}
+
+	dirty := drainRelatedDirty()
+	if len(dirty) == 0 {
+		return nil
+	}
+	count, err := refreshRelatedPosts(generation, dirty)
+	if err != nil {
+		// 放回标记，下一轮重试
+		MarkRelatedPostsDirty(dirty)
+		return err
+	}
+	fmt.Printf("[related] refreshed related posts for %d posts\n", count)
+	return nil
+}
+
+// PrecomputeRelatedPosts 为所有已发布文章计算相关度并写入指定版本的缓存，返回计算的文章数
+func PrecomputeRelatedPosts(generation int64) (int, error) {
+	corpus, err := buildRelatedCorpus()
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	for _, id := range corpus.order {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		storeRelatedCache(ctx, generation, id, corpus.score(id, now))
+		cancel()
+	}
+	return len(corpus.order), nil
+}
+
+// refreshRelatedPosts 重新计算被标记文章及与其共享分类、标签的文章，返回写入缓存的文章数。
+// 其他文章列表中对被标记文章的评分可能略有滞后，缓存过期或下一次整体预计算后即恢复；
+// 已下线或改为私密的文章在读取时过滤
+func refreshRelatedPosts(generation int64, dirty []uint64) (int, error) {
+	corpus, err := buildRelatedCorpus()
+	if err != nil {
+		return 0, err
+	}
+	affected := corpus.affectedBy(dirty)
+	now := time.Now()
+	for _, id := range affected {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		storeRelatedCache(ctx, generation, id, corpus.score(id, now))
+		cancel()
+	}
+	return len(affected), nil
+}
+
+// sharedRelatedCorpus 缓存未命中时按版本共用候选集：同时到达的请求只构建一次，
+// 构建完成后 relatedCorpusReuse 内的其他未命中直接复用，避免每个请求都全量加载文章
+func sharedRelatedCorpus(generation int64) (*relatedCorpus, error) {
+	relatedCorpusMu.Lock()
+	call := relatedCorpusFlight
+	if call != nil && call.generation == generation {
+		select {
+		case <-call.done:
+			if call.err != nil || time.Since(call.builtAt) > relatedCorpusReuse {
+				call = nil
+			}
+		default:
+		}
+	} else {
+		call = nil
+	}
+	if call != nil {
+		relatedCorpusMu.Unlock()
+		<-call.done
+		return call.corpus, call.err
+	}
+
+	call = &relatedCorpusCall{generation: generation, done: make(chan struct{})}
+	relatedCorpusFlight = call
+	relatedCorpusMu.Unlock()
+
+	call.corpus, call.err = buildRelatedCorpus()
+	call.builtAt = time.Now()
+	close(call.done)
+	return call.corpus, call.err
+}
+
+// buildRelatedCorpus 加载候选文章及其标签、分类，并用分类/标签关键词体系提取文本特征
+func buildRelatedCorpus() (*relatedCorpus, error) {
+	candidates, err := dao.ListRelatedCandidates()
+	if err != nil {
+		return nil, err
+	}
+	postTags, err := dao.ListAllPostTagIDs()
+	if err != nil {
+		return nil, err
+	}
+	postCategories, err := dao.ListAllPostCategoryIDs()
+	if err != nil {
+		return nil, err
+	}
+	keywords, err := relatedTaxonomyKeywords()
+	if err != nil {
+		return nil, err
+	}
+
+	corpus := &relatedCorpus{
+		docs:  make(map[uint64]*relatedDoc, len(candidates)),
+		order: make([]uint64, 0, len(candidates)),
+	}
+	for _, candidate := range candidates {
+		publishedAt := candidate.CreatedAt
+		if candidate.PublishedAt != nil {
+			publishedAt = *candidate.PublishedAt
+		}
+		text := normalizeMatchText(strings.Join([]string{
+			candidate.Title, candidate.Excerpt, markdownToPlainText(candidate.Content),
+		}, "\n"))
+
+		doc := &relatedDoc{
+			id:          candidate.ID,
+			publishedAt: publishedAt,
+			tags:        toIDSet(postTags[candidate.ID]),
+			categories:  toIDSet(postCategories[candidate.ID]),
+			keywords:    make(map[string]struct{}),
+		}
+		_, matched := taxonomyScore(text, keywords)
+		for _, keyword := range matched {
+			doc.keywords[keyword] = struct{}{}
+		}
+		for _, token := range splitKeywords(normalizeMatchText(candidate.Title)) {
+			if shouldKeepKeyword(token) {
+				doc.keywords[token] = struct{}{}
+			}
+		}
+
+		corpus.docs[candidate.ID] = doc
+		corpus.order = append(corpus.order, candidate.ID)
+	}
+	return corpus, nil
+}
+
+// relatedTaxonomyKeywords 汇总所有分类、标签的关键词（含同义词）
+func relatedTaxonomyKeywords() ([]taxonomyKeyword, error) {
+	categories, err := ListCategories()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := ListTags()
+	if err != nil {
+		return nil, err
+	}
+
+	keywords := make([]taxonomyKeyword, 0, (len(categories)+len(tags))*6)
+	seen := make(map[string]struct{})
+	appendUnique := func(items []taxonomyKeyword) {
+		for _, item := range items {
+			if _, ok := seen[item.Value]; ok {
+				continue
+			}
+			seen[item.Value] = struct{}{}
+			keywords = append(keywords, item)
+		}
+	}
+	for _, category := range categories {
+		appendUnique(buildCategoryKeywords(category))
+	}
+	for _, tag := range tags {
+		appendUnique(buildTagKeywords(tag))
+	}
+	return keywords, nil
+}
+
+// score 计算 sourceID 与其他候选文章的相关度，返回按分数排序的前 relatedCacheSize 篇
+func (c *relatedCorpus) score(sourceID uint64, now time.Time) []RelatedScore {
+	source, ok := c.docs[sourceID]
+	if !ok {
+		return []RelatedScore{}
+	}
+
+	scores := make([]RelatedScore, 0, len(c.order))
+	for _, id := range c.order {
+		if id == sourceID {
+			continue
+		}
+		candidate := c.docs[id]
+		sharedTags := countShared(source.tags, candidate.tags)
+		sharedCategories := countShared(source.categories, candidate.categories)
+		similarity := jaccard(source.keywords, candidate.keywords)
+		recency := math.Pow(0.5, now.Sub(candidate.publishedAt).Hours()/relatedRecencyHalf.Hours())
+		if recency > 1 {
+			recency = 1
+		}
+
+		total := relatedTagWeight*float64(sharedTags) +
+			relatedCategoryWeight*float64(sharedCategories) +
+			relatedTextWeight*similarity +
+			relatedRecencyWeight*recency
+		scores = append(scores, RelatedScore{
+			PostID:           id,
+			Score:            math.Round(total*10000) / 10000,
+			SharedTags:       sharedTags,
+			SharedCategories: sharedCategories,
+			TextSimilarity:   math.Round(similarity*10000) / 10000,
+		})
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score == scores[j].Score {
+			return c.docs[scores[i].PostID].publishedAt.After(c.docs[scores[j].PostID].publishedAt)
+		}
+		return scores[i].Score > scores[j].Score
+	})
+	if len(scores) > relatedCacheSize {
+		scores = scores[:relatedCacheSize]
+	}
+	return scores
+}
+
+// affectedBy 返回 ids 中仍为候选的文章，以及与它们共享分类或标签的文章
+func (c *relatedCorpus) affectedBy(ids []uint64) []uint64 {
+	changed := make(map[uint64]struct{}, len(ids))
+	tags := make(map[uint64]struct{})
+	categories := make(map[uint64]struct{})
+	for _, id := range ids {
+		doc, ok := c.docs[id]
+		if !ok {
+			continue
+		}
+		changed[id] = struct{}{}
+		for tagID := range doc.tags {
+			tags[tagID] = struct{}{}
+		}
+		for categoryID := range doc.categories {
+			categories[categoryID] = struct{}{}
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	var affected []uint64
+	for _, id := range c.order {
+		doc := c.docs[id]
+		if _, ok := changed[id]; ok || countShared(doc.tags, tags) > 0 || countShared(doc.categories, categories) > 0 {
+			affected = append(affected, id)
+		}
+	}
+	return affected
+}
+
+func toIDSet(ids []uint64) map[uint64]struct{} {
+	set := make(map[uint64]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+func countShared(a, b map[uint64]struct{}) int {
+	count := 0
+	for id := range a {
+		if _, ok := b[id]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for key := range a {
+		if _, ok := b[key]; ok {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// relatedGeneration 当前缓存版本：启用 Redis 时多实例共享，否则使用进程内版本
+func relatedGeneration(ctx context.Context) int64 {
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		return localRelatedGeneration.Load()
+	}
+	generation, err := client.Get(ctx, relatedGenerationKey).Int64()
+	if err != nil && err != redis.Nil {
+		fmt.Printf("[related] redis get generation error: %v\n", err)
+		return localRelatedGeneration.Load()
+	}
+	return generation
+}
+
+// relatedWarmedGeneration 最近一次完成整体预计算的版本。Redis 中该标记的过期时间短于缓存，
+// 到期后后台任务会在缓存过期前重新整体计算
+func relatedWarmedGeneration(ctx context.Context) (int64, bool) {
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		localRelatedMu.Lock()
+		defer localRelatedMu.Unlock()
+		return localRelatedWarmed, localRelatedWarmedOK
+	}
+	generation, err := client.Get(ctx, relatedWarmedKey).Int64()
+	if err != nil {
+		if err != redis.Nil {
+			fmt.Printf("[related] redis get warmed generation error: %v\n", err)
+		}
+		return 0, false
+	}
+	return generation, true
+}
+
+func setRelatedWarmedGeneration(generation int64) {
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		localRelatedMu.Lock()
+		defer localRelatedMu.Unlock()
+		localRelatedWarmed, localRelatedWarmedOK = generation, true
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Set(ctx, relatedWarmedKey, generation, relatedCacheTTL/2).Err(); err != nil {
+		fmt.Printf("[related] redis set warmed generation error: %v\n", err)
+	}
+}
+
+// drainRelatedDirty 取出并清除全部待重新计算的文章 ID
+func drainRelatedDirty() []uint64 {
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		localRelatedMu.Lock()
+		defer localRelatedMu.Unlock()
+		ids := make([]uint64, 0, len(localRelatedDirty))
+		for id := range localRelatedDirty {
+			ids = append(ids, id)
+		}
+		localRelatedDirty = make(map[uint64]struct{})
+		return ids
+	}
+
+	var ids []uint64
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		members, err := client.SPopN(ctx, relatedDirtyKey, relatedDirtyBatch).Result()
+		cancel()
+		if err != nil {
+			if err != redis.Nil {
+				fmt.Printf("[related] redis pop dirty error: %v\n", err)
+			}
+			return ids
+		}
+		for _, member := range members {
+			if id, err := strconv.ParseUint(member, 10, 64); err == nil {
+				ids = append(ids, id)
+			}
+		}
+		if len(members) < relatedDirtyBatch {
+			return ids
+		}
+	}
+}
+
+func relatedCacheKey(generation int64, postID uint64) string {
+	return relatedCacheKeyPrefix + strconv.FormatInt(generation, 10) + ":" + strconv.FormatUint(postID, 10)
+}
+
+func loadRelatedCache(ctx context.Context, generation int64, postID uint64) ([]RelatedScore, bool) {
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		localRelatedMu.Lock()
+		defer localRelatedMu.Unlock()
+		entry, ok := localRelatedCache[postID]
+		if !ok || entry.generation != generation {
+			return nil, false
+		}
+		entry.lastUsed = time.Now()
+		return entry.scores, true
+	}
+
+	value, err := client.Get(ctx, relatedCacheKey(generation, postID)).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			fmt.Printf("[related] redis get error: %v\n", err)
+		}
+		return nil, false
+	}
+	var scores []RelatedScore
+	if err := json.Unmarshal(value, &scores); err != nil {
+		return nil, false
+	}
+	return scores, true
+}
+
+// loadStaleRelatedCache 读取最近一次整体预计算版本（进程内为任意版本）的结果
+func loadStaleRelatedCache(ctx context.Context, postID uint64) ([]RelatedScore, bool) {
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		localRelatedMu.Lock()
+		defer localRelatedMu.Unlock()
+		entry, ok := localRelatedCache[postID]
+		if !ok {
+			return nil, false
+		}
+		entry.lastUsed = time.Now()
+		return entry.scores, true
+	}
+	warmed, ok := relatedWarmedGeneration(ctx)
+	if !ok {
+		return nil, false
+	}
+	return loadRelatedCache(ctx, warmed, postID)
+}
+
+func storeRelatedCache(ctx context.Context, generation int64, postID uint64, scores []RelatedScore) {
+	client, err := redisstore.GetClient()
+	if err != nil || client == nil {
+		localRelatedMu.Lock()
+		defer localRelatedMu.Unlock()
+		if entry, ok := localRelatedCache[postID]; ok && generation < entry.generation {
+			// 旧版本的计算结果直接丢弃
+			return
+		}
+		if _, ok := localRelatedCache[postID]; !ok && len(localRelatedCache) >= relatedLocalCacheSize {
+			evictOldestLocalRelated()
+		}
+		localRelatedCache[postID] = &localRelatedEntry{generation: generation, lastUsed: time.Now(), scores: scores}
+		return
+	}
+
+	payload, err := json.Marshal(scores)
+	if err != nil {
+		return
+	}
+	if err := client.Set(ctx, relatedCacheKey(generation, postID), payload, relatedCacheTTL).Err(); err != nil {
+		fmt.Printf("[related] redis set error: %v\n", err)
+	}
+}
+
+// evictOldestLocalRelated 淘汰进程内最久未使用的缓存项，调用方需持有锁
+func evictOldestLocalRelated() {
+	var oldestID uint64
+	var oldest time.Time
+	for id, entry := range localRelatedCache {
+		if oldest.IsZero() || entry.lastUsed.Before(oldest) {
+			oldestID = id
+			oldest = entry.lastUsed
+		}
+	}
+	delete(localRelatedCache, oldestID)
+}
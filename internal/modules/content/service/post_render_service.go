@@ -0,0 +1,293 @@
+package service
+
+import (
+	"api/internal/modules/content/models"
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	maxTOCLevel        = 4   // 目录只收录 h1-h4
+	hanCharsPerMinute  = 300 // 中文阅读速度（字/分钟）
+	wordsPerMinute     = 200 // 英文阅读速度（词/分钟）
+	renderCacheMaxSize = 512 // 进程内渲染缓存最多保留的文章数
+)
+
+// TOCItem 目录树节点
+type TOCItem struct {
+	Level    int       `json:"level"`
+	Text     string    `json:"text"`
+	Anchor   string    `json:"anchor"`
+	Children []TOCItem `json:"children,omitempty"`
+}
+
+// RenderedContent 文章正文渲染结果
+type RenderedContent struct {
+	HTML           string    `json:"html"`
+	TOC            []TOCItem `json:"toc"`
+	WordCount      int       `json:"word_count"`
+	ReadingMinutes int       `json:"reading_minutes"`
+}
+
+type renderCacheEntry struct {
+	updatedAt time.Time
+	lastUsed  time.Time
+	result    *RenderedContent
+}
+
+var (
+	renderCacheMu sync.Mutex
+	renderCache   = make(map[uint64]*renderCacheEntry)
+
+	markdown = goldmark.New(
+		goldmark.WithExtensions(extension.GFM, extension.Footnote),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		// 允许作者在 Markdown 中书写原始 HTML，输出前统一经过 sanitizer 过滤
+		goldmark.WithRendererOptions(gmhtml.WithUnsafe()),
+	)
+
+	htmlPolicy = newContentPolicy()
+)
+
+func newContentPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	headingIDPattern := regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+	p.AllowAttrs("id").Matching(headingIDPattern).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^heading-anchor$`)).OnElements("a")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	return p
+}
+
+// RenderPostContent 渲染文章正文，结果按文章ID缓存，UpdatedAt 变化后自动失效
+func RenderPostContent(post *models.Post) *RenderedContent {
+	renderCacheMu.Lock()
+	if entry, ok := renderCache[post.ID]; ok && entry.updatedAt.Equal(post.UpdatedAt) {
+		entry.lastUsed = time.Now()
+		renderCacheMu.Unlock()
+		return entry.result
+	}
+	renderCacheMu.Unlock()
+
+	result := RenderMarkdown(post.Content)
+
+	renderCacheMu.Lock()
+	defer renderCacheMu.Unlock()
+	if len(renderCache) >= renderCacheMaxSize {
+		evictOldestRenderCache()
+	}
+	renderCache[post.ID] = &renderCacheEntry{updatedAt: post.UpdatedAt, lastUsed: time.Now(), result: result}
+	return result
+}
+
+// InvalidatePostRender 主动移除文章的渲染缓存
+func InvalidatePostRender(postID uint64) {
+	renderCacheMu.Lock()
+	delete(renderCache, postID)
+	renderCacheMu.Unlock()
+}
+
+// evictOldestRenderCache 淘汰最久未使用的缓存项，调用方需持有锁
+func evictOldestRenderCache() {
+	var oldestID uint64
+	var oldest time.Time
+	for id, entry := range renderCache {
+		if oldest.IsZero() || entry.lastUsed.Before(oldest) {
+			oldestID = id
+			oldest = entry.lastUsed
+		}
+	}
+	delete(renderCache, oldestID)
+}
+
+// RenderMarkdown 将 Markdown 渲染为安全的 HTML，并生成目录、标题锚点、字数与预计阅读时间
+func RenderMarkdown(content string) *RenderedContent {
+	source := []byte(content)
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	doc := markdown.Parser().Parse(text.NewReader(source), parser.WithContext(ctx))
+
+	headings := collectHeadings(doc, source)
+
+	var buf bytes.Buffer
+	if err := markdown.Renderer().Render(&buf, source, doc); err != nil {
+		// 渲染失败时退回转义后的原文，保证接口可用
+		fmt.Printf("[render] markdown render error: %v\n", err)
+		buf.Reset()
+		buf.WriteString("<pre>" + html.EscapeString(content) + "</pre>")
+	}
+
+	plain := markdownToPlainText(content)
+	words := countWords(plain)
+	return &RenderedContent{
+		HTML:           htmlPolicy.Sanitize(buf.String()),
+		TOC:            buildTOC(headings),
+		WordCount:      words.total(),
+		ReadingMinutes: words.readingMinutes(),
+	}
+}
+
+// collectHeadings 收集标题节点并在标题末尾追加锚点链接
+func collectHeadings(doc ast.Node, source []byte) []TOCItem {
+	headings := make([]TOCItem, 0, 8)
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		heading, ok := n.(*ast.Heading)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+
+		anchor := ""
+		if id, found := heading.AttributeString("id"); found {
+			if b, ok := id.([]byte); ok {
+				anchor = string(b)
+			}
+		}
+		title := strings.TrimSpace(string(nodeText(heading, source)))
+		if anchor != "" {
+			link := ast.NewLink()
+			link.Destination = []byte("#" + anchor)
+			link.SetAttributeString("class", []byte("heading-anchor"))
+			link.AppendChild(link, ast.NewString([]byte("#")))
+			heading.AppendChild(heading, link)
+		}
+		if heading.Level <= maxTOCLevel && title != "" {
+			headings = append(headings, TOCItem{Level: heading.Level, Text: title, Anchor: anchor})
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	return headings
+}
+
+// nodeText 拼接节点下所有文本内容
+func nodeText(n ast.Node, source []byte) []byte {
+	var buf bytes.Buffer
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		switch node := child.(type) {
+		case *ast.Text:
+			buf.Write(node.Segment.Value(source))
+		case *ast.String:
+			buf.Write(node.Value)
+		default:
+			buf.Write(nodeText(child, source))
+		}
+	}
+	return buf.Bytes()
+}
+
+// buildTOC 将平铺的标题列表按层级组装成树
+func buildTOC(headings []TOCItem) []TOCItem {
+	root := &TOCItem{Level: 0}
+	stack := []*TOCItem{root}
+	for _, heading := range headings {
+		for len(stack) > 1 && stack[len(stack)-1].Level >= heading.Level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		parent.Children = append(parent.Children, heading)
+		stack = append(stack, &parent.Children[len(parent.Children)-1])
+	}
+	if root.Children == nil {
+		return []TOCItem{}
+	}
+	return root.Children
+}
+
+// headingIDs 生成标题锚点，保留中文等 Unicode 字符（goldmark 默认实现会丢弃非 ASCII 字符）
+type headingIDs struct {
+	values map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{values: map[string]bool{}}
+}
+
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	var builder strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(string(value))) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			builder.WriteRune(r)
+			lastDash = false
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			if !lastDash && builder.Len() > 0 {
+				builder.WriteRune('-')
+				lastDash = true
+			}
+		}
+	}
+	id := strings.TrimSuffix(builder.String(), "-")
+	if id == "" {
+		id = "heading"
+	}
+	if !s.values[id] {
+		s.values[id] = true
+		return []byte(id)
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", id, i)
+		if !s.values[candidate] {
+			s.values[candidate] = true
+			return []byte(candidate)
+		}
+	}
+}
+
+func (s *headingIDs) Put(value []byte) {
+	s.values[string(value)] = true
+}
+
+type wordStats struct {
+	han   int
+	words int
+}
+
+func (w wordStats) total() int {
+	return w.han + w.words
+}
+
+// readingMinutes 中文按字、英文按词分别估算，至少 1 分钟
+func (w wordStats) readingMinutes() int {
+	minutes := float64(w.han)/hanCharsPerMinute + float64(w.words)/wordsPerMinute
+	if minutes < 1 {
+		return 1
+	}
+	return int(math.Ceil(minutes))
+}
+
+// countWords 统计汉字数与非汉字单词数
+func countWords(plain string) wordStats {
+	stats := wordStats{}
+	inWord := false
+	for _, r := range plain {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			stats.han++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if !inWord {
+				stats.words++
+				inWord = true
+			}
+		default:
+			inWord = false
+		}
+	}
+	return stats
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"strings"
+)
+
+const (
+	RevisionReasonUpdate  = "update"
+	RevisionReasonRestore = "restore"
+)
+
+// PostRevisionWrite 把更新前的快照 before 作为一条修订，与文章保存在同一事务中写入；
+// 保存失败（如版本冲突）时不会留下修订，重试也不会重复占用修订数量
+func PostRevisionWrite(before *models.Post, editorID uint64, reason string) ContentWrite {
+	return func(t *dao.ContentTx) error {
+		if reason == "" {
+			reason = RevisionReasonUpdate
+		}
+		return t.CreatePostRevision(&models.PostRevision{
+			PostID:   before.ID,
+			Title:    before.Title,
+			Excerpt:  before.Excerpt,
+			Content:  before.Content,
+			EditorID: editorID,
+			Reason:   reason,
+		}, config.Load().PostRevisionLimit)
+	}
+}
+
+// PostRevisionChanged 判断更新前后的文本字段是否发生变化（仅状态等字段变化时无需保存修订）
+func PostRevisionChanged(before, after *models.Post) bool {
+	return before.Title != after.Title ||
+		before.Excerpt != after.Excerpt ||
+		before.Content != after.Content
+}
+
+func ListPostRevisions(postID uint64) ([]models.PostRevision, error) {
+	return dao.ListPostRevisions(postID)
+}
+
+func GetPostRevision(postID, revisionID uint64) (*models.PostRevision, error) {
+	return dao.GetPostRevision(postID, revisionID)
+}
+
+// RestorePostRevision 将文章恢复到指定修订，恢复前会把当前内容也保存为一条修订，保证可以撤销；
+// version 为编辑者看到的版本号，文章已被修改时返回 ErrVersionConflict
+func RestorePostRevision(postID, revisionID, editorID, version uint64) (*models.Post, error) {
+	post, err := dao.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Version != version {
+		return nil, ErrVersionConflict
+	}
+	rev, err := dao.GetPostRevision(postID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *post
+	post.Title = rev.Title
+	post.Excerpt = rev.Excerpt
+	post.Content = rev.Content
+	if err := UpdatePost(post, PostRevisionWrite(&before, editorID, RevisionReasonRestore)); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// 差异行类型
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffLine 行级差异中的一行，OldLine/NewLine 为 1 起始的行号，不存在时为 0
+type DiffLine struct {
+	Op      string `json:"op"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+	Text    string `json:"text"`
+}
+
+// RevisionDiff 两个版本之间的差异结果
+type RevisionDiff struct {
+	From         DiffSide   `json:"from"`
+	To           DiffSide   `json:"to"`
+	TitleChanged bool       `json:"title_changed"`
+	Added        int        `json:"added"`
+	Removed      int        `json:"removed"`
+	Lines        []DiffLine `json:"lines"`
+}
+
+// DiffSide 描述参与对比的一侧，RevisionID 为 0 表示文章当前内容
+type DiffSide struct {
+	RevisionID uint64 `json:"revision_id"`
+	RevisionNo int    `json:"revision_no"`
+	Title      string `json:"title"`
+}
+
+// DiffPostRevisions 对比两个修订的正文，toID 为 0 时与文章当前内容对比
+func DiffPostRevisions(postID, fromID, toID uint64) (*RevisionDiff, error) {
+	from, err := revisionSide(postID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := revisionSide(postID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := DiffTextLines(from.Content, to.Content)
+	result := &RevisionDiff{
+		From:         DiffSide{RevisionID: fromID, RevisionNo: from.RevisionNo, Title: from.Title},
+		To:           DiffSide{RevisionID: toID, RevisionNo: to.RevisionNo, Title: to.Title},
+		TitleChanged: from.Title != to.Title,
+		Lines:        lines,
+	}
+	for _, line := range lines {
+		switch line.Op {
+		case DiffInsert:
+			result.Added++
+		case DiffDelete:
+			result.Removed++
+		}
+	}
+	return result, nil
+}
+
+func revisionSide(postID, revisionID uint64) (*models.PostRevision, error) {
+	if revisionID == 0 {
+		post, err := dao.GetPostByID(postID)
+		if err != nil {
+			return nil, err
+		}
+		return &models.PostRevision{PostID: post.ID, Title: post.Title, Excerpt: post.Excerpt, Content: post.Content}, nil
+	}
+	return dao.GetPostRevision(postID, revisionID)
+}
+
+// DiffTextLines 基于 Myers 算法计算两段文本的行级差异
+func DiffTextLines(oldText, newText string) []DiffLine {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// 先剥离公共前后缀，缩小实际参与计算的区间
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]DiffLine, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		result = append(result, DiffLine{Op: DiffEqual, OldLine: i + 1, NewLine: i + 1, Text: a[i]})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	for _, line := range myersDiff(midA, midB) {
+		if line.OldLine > 0 {
+			line.OldLine += prefix
+		}
+		if line.NewLine > 0 {
+			line.NewLine += prefix
+		}
+		result = append(result, line)
+	}
+
+	for i := 0; i < suffix; i++ {
+		oldIdx := len(a) - suffix + i
+		newIdx := len(b) - suffix + i
+		result = append(result, DiffLine{Op: DiffEqual, OldLine: oldIdx + 1, NewLine: newIdx + 1, Text: a[oldIdx]})
+	}
+	return result
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(text, "\n")
+}
+
+// diffMaxSnakeSteps 单次求中间蛇时最多推进的步数，超出后该区间按整体替换输出，
+// 避免差异极大的长文本在 O((N+M)·D) 的计算上耗时过久
+const diffMaxSnakeSteps = 1000
+
+// myersDiff 返回 a -> b 的编辑脚本，行号相对于传入切片，1 起始。
+// 采用线性空间的 Myers 算法：每次只求中间蛇（middle snake）作为切分点再分治递归，
+// 额外内存为 O(N+M)，不再为每一步 D 保存完整的 V 数组快照；
+// 差异过大的区间退化为整体替换，结果仍是合法的编辑脚本，只是不再保证最短
+func myersDiff(a, b []string) []DiffLine {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	d := &myersDiffer{a: a, b: b, lines: make([]DiffLine, 0, len(a)+len(b))}
+	d.compare(0, len(a), 0, len(b))
+	return d.lines
+}
+
+type myersDiffer struct {
+	a, b  []string
+	lines []DiffLine
+}
+
+// compare 按顺序输出 a[aLo:aHi] -> b[bLo:bHi] 的编辑脚本
+func (d *myersDiffer) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.equal(aLo, bLo)
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aHi-suffix > aLo && bHi-suffix > bLo && d.a[aHi-1-suffix] == d.b[bHi-1-suffix] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		d.insert(bLo, bHi)
+	case bLo == bHi:
+		d.remove(aLo, aHi)
+	default:
+		x, y, ok := d.middleSnake(aLo, aHi, bLo, bHi)
+		if ok && (x > aLo || y > bLo) && (x < aHi || y < bHi) {
+			d.compare(aLo, x, bLo, y)
+			d.compare(x, aHi, y, bHi)
+		} else {
+			// 差异过大或找不到有效切分点时整体替换，保证递归一定收敛
+			d.remove(aLo, aHi)
+			d.insert(bLo, bHi)
+		}
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.equal(aHi+i, bHi+i)
+	}
+}
+
+// middleSnake 从两端同时推进，返回正反两条最远路径重叠处的切分点（绝对下标）
+func (d *myersDiffer) middleSnake(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	size := 2*maxD + 2
+	vf := make([]int, size)
+	vb := make([]int, size)
+	for i := range vf {
+		vf[i] = -1
+		vb[i] = -1
+	}
+	vf[offset+1] = 0
+	vb[offset+1] = 0
+
+	delta := n - m
+	// delta 为奇数时在正向推进中检测重叠，偶数时在反向推进中检测
+	front := delta%2 != 0
+	// 路径越出编辑图边界后收窄对应一侧的对角线范围
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+	for step := 0; step < maxD && step < diffMaxSnakeSteps; step++ {
+		for k := -step + fStart; k <= step-fEnd; k += 2 {
+			ko := offset + k
+			var x int
+			if k == -step || (k != step && vf[ko-1] < vf[ko+1]) {
+				x = vf[ko+1]
+			} else {
+				x = vf[ko-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			vf[ko] = x
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case front:
+				bo := offset + delta - k
+				if bo >= 0 && bo < size && vb[bo] != -1 && x >= n-vb[bo] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+
+		for k := -step + bStart; k <= step-bEnd; k += 2 {
+			ko := offset + k
+			var x int
+			if k == -step || (k != step && vb[ko-1] < vb[ko+1]) {
+				x = vb[ko+1]
+			} else {
+				x = vb[ko-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aHi-1-x] == d.b[bHi-1-y] {
+				x++
+				y++
+			}
+			vb[ko] = x
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !front:
+				fo := offset + delta - k
+				if fo >= 0 && fo < size && vf[fo] != -1 {
+					fx := vf[fo]
+					if fx >= n-x {
+						return aLo + fx, bLo + fx - (fo - offset), true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (d *myersDiffer) equal(x, y int) {
+	d.lines = append(d.lines, DiffLine{Op: DiffEqual, OldLine: x + 1, NewLine: y + 1, Text: d.a[x]})
+}
+
+func (d *myersDiffer) remove(lo, hi int) {
+	for x := lo; x < hi; x++ {
+		d.lines = append(d.lines, DiffLine{Op: DiffDelete, OldLine: x + 1, Text: d.a[x]})
+	}
+}
+
+func (d *myersDiffer) insert(lo, hi int) {
+	for y := lo; y < hi; y++ {
+		d.lines = append(d.lines, DiffLine{Op: DiffInsert, NewLine: y + 1, Text: d.b[y]})
+	}
+}
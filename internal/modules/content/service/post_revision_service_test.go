@@ -0,0 +1,139 @@
+package service
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// 按编辑脚本还原两侧文本，并校验行号连续
+func applyDiff(t *testing.T, lines []DiffLine) (string, string) {
+	t.Helper()
+	var oldLines, newLines []string
+	for _, line := range lines {
+		if line.Op != DiffInsert {
+			oldLines = append(oldLines, line.Text)
+			if line.OldLine != len(oldLines) {
+				t.Fatalf("old_line = %d, want %d", line.OldLine, len(oldLines))
+			}
+		}
+		if line.Op != DiffDelete {
+			newLines = append(newLines, line.Text)
+			if line.NewLine != len(newLines) {
+				t.Fatalf("new_line = %d, want %d", line.NewLine, len(newLines))
+			}
+		}
+	}
+	return strings.Join(oldLines, "\n"), strings.Join(newLines, "\n")
+}
+
+// 最长公共子序列长度，用于校验编辑脚本是最短的
+func lcsLen(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i-1] == b[j-1]:
+				cur[j] = prev[j-1] + 1
+			case prev[j] >= cur[j-1]:
+				cur[j] = prev[j]
+			default:
+				cur[j] = cur[j-1]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func TestDiffTextLines(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new string
+		ops      string
+	}{
+		{"相同", "a\nb", "a\nb", "=="},
+		{"新增", "", "a\nb", "++"},
+		{"删除", "a\nb", "", "--"},
+		{"中间修改", "a\nb\nc", "a\nx\nc", "=-+="},
+		{"整体替换", "a", "b", "-+"},
+		{"插入与删除", "a\nb\nc\nd", "b\nc\nx\nd", "-==+="},
+	}
+	symbols := map[string]string{DiffEqual: "=", DiffInsert: "+", DiffDelete: "-"}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := DiffTextLines(tc.old, tc.new)
+			var ops strings.Builder
+			for _, line := range lines {
+				ops.WriteString(symbols[line.Op])
+			}
+			if ops.String() != tc.ops {
+				t.Fatalf("ops = %q, want %q", ops.String(), tc.ops)
+			}
+			if gotOld, gotNew := applyDiff(t, lines); gotOld != tc.old || gotNew != tc.new {
+				t.Fatalf("applyDiff() = %q, %q", gotOld, gotNew)
+			}
+		})
+	}
+}
+
+func TestDiffTextLinesMinimal(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomText := func() []string {
+		lines := make([]string, rnd.Intn(40))
+		for i := range lines {
+			lines[i] = strconv.Itoa(rnd.Intn(5))
+		}
+		return lines
+	}
+	for i := 0; i < 500; i++ {
+		a, b := randomText(), randomText()
+		oldText, newText := strings.Join(a, "\n"), strings.Join(b, "\n")
+		lines := DiffTextLines(oldText, newText)
+		if gotOld, gotNew := applyDiff(t, lines); gotOld != oldText || gotNew != newText {
+			t.Fatalf("applyDiff(%q, %q) = %q, %q", oldText, newText, gotOld, gotNew)
+		}
+		equal := 0
+		for _, line := range lines {
+			if line.Op == DiffEqual {
+				equal++
+			}
+		}
+		if want := lcsLen(splitLines(oldText), splitLines(newText)); equal != want {
+			t.Fatalf("DiffTextLines(%q, %q) keeps %d lines, want %d", oldText, newText, equal, want)
+		}
+	}
+}
+
+func TestDiffTextLinesLargeRewrite(t *testing.T) {
+	// 两侧几乎完全不同的大文本超出步数上限，按整体替换输出
+	a := make([]string, 20000)
+	b := make([]string, 20000)
+	for i := range a {
+		a[i] = "old " + strconv.Itoa(i)
+		b[i] = "new " + strconv.Itoa(i)
+	}
+	b[10000] = a[5000]
+	oldText, newText := strings.Join(a, "\n"), strings.Join(b, "\n")
+	lines := DiffTextLines(oldText, newText)
+	if len(lines) != 40000 {
+		t.Fatalf("len(lines) = %d, want 40000", len(lines))
+	}
+	if gotOld, gotNew := applyDiff(t, lines); gotOld != oldText || gotNew != newText {
+		t.Fatal("applyDiff() does not restore both sides")
+	}
+
+	// 改动分散但总量在上限内时仍给出最短脚本
+	for i := range b {
+		b[i] = a[i]
+		if i%50 == 0 {
+			b[i] = "new " + strconv.Itoa(i)
+		}
+	}
+	lines = DiffTextLines(oldText, strings.Join(b, "\n"))
+	if len(lines) != 20400 {
+		t.Fatalf("len(lines) = %d, want 20400", len(lines))
+	}
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	snippetRunes       = 160 // 摘要片段长度
+	snippetLeadingRune = 40  // 首个命中位置之前保留的字符数
+)
+
+// PostSearchResult 检索结果中的单篇文章
+type PostSearchResult struct {
+	models.PostWithRelations
+	Score          float64 `json:"score"`
+	MatchCount     int     `json:"match_count"`
+	HighlightTitle string  `json:"highlight_title"`
+	Snippet        string  `json:"snippet"`
+}
+
+// PostSearchResponse 检索分页响应
+type PostSearchResponse struct {
+	Posts      []PostSearchResult `json:"posts"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+	Query      string             `json:"q"`
+}
+
+// SearchPosts 按相关度检索文章，并生成高亮标题、正文片段与命中次数
+func SearchPosts(page, pageSize int, q, category, tag, author, status, visibility, lang string) (*PostSearchResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	q = strings.TrimSpace(q)
+
+	hits, total, err := dao.SearchPosts(page, pageSize, q, category, tag, author, status, visibility, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint64, 0, len(hits))
+	scores := make(map[uint64]float64, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+		scores[hit.ID] = hit.Score
+	}
+	posts, err := dao.ListPostsByOrderedIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := searchTerms(q)
+	results := make([]PostSearchResult, 0, len(posts))
+	for _, post := range posts {
+		plain := markdownToPlainText(post.Content)
+		results = append(results, PostSearchResult{
+			PostWithRelations: post,
+			Score:             math.Round(scores[post.ID]*10000) / 10000,
+			MatchCount:        countMatches(post.Title, terms) + countMatches(post.Excerpt, terms) + countMatches(plain, terms),
+			HighlightTitle:    highlightText(post.Title, terms),
+			Snippet:           buildSnippet(plain, post.Excerpt, terms),
+		})
+	}
+
+	return &PostSearchResponse{
+		Posts:      results,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+		Query:      q,
+	}, nil
+}
+
+// searchTerms 拆分检索词：按空白分词，去重并按长度倒序（优先高亮较长的词）
+func searchTerms(q string) [][]rune {
+	seen := make(map[string]struct{})
+	terms := make([][]rune, 0, 4)
+	for _, field := range strings.Fields(q) {
+		lower := strings.ToLower(field)
+		if _, ok := seen[lower]; ok {
+			continue
+		}
+		seen[lower] = struct{}{}
+		terms = append(terms, lowerRunes([]rune(field)))
+	}
+	sort.SliceStable(terms, func(i, j int) bool { return len(terms[i]) > len(terms[j]) })
+	return terms
+}
+
+func lowerRunes(runes []rune) []rune {
+	lowered := make([]rune, len(runes))
+	for i, r := range runes {
+		lowered[i] = unicode.ToLower(r)
+	}
+	return lowered
+}
+
+type matchRange struct{ start, end int }
+
+// findMatches 在文本中查找所有不重叠的命中区间（按 rune 下标，大小写不敏感）
+func findMatches(text []rune, terms [][]rune) []matchRange {
+	if len(terms) == 0 || len(text) == 0 {
+		return nil
+	}
+	lowered := lowerRunes(text)
+	ranges := make([]matchRange, 0, 8)
+	for i := 0; i < len(lowered); {
+		matched := 0
+		for _, term := range terms {
+			if len(term) > 0 && hasRunePrefix(lowered[i:], term) {
+				matched = len(term)
+				break
+			}
+		}
+		if matched > 0 {
+			ranges = append(ranges, matchRange{start: i, end: i + matched})
+			i += matched
+			continue
+		}
+		i++
+	}
+	return ranges
+}
+
+func hasRunePrefix(text, prefix []rune) bool {
+	if len(text) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if text[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func countMatches(text string, terms [][]rune) int {
+	return len(findMatches([]rune(text), terms))
+}
+
+// highlightText 转义 HTML 后用 <mark> 包裹命中词
+func highlightText(text string, terms [][]rune) string {
+	runes := []rune(text)
+	return renderHighlighted(runes, findMatches(runes, terms))
+}
+
+func renderHighlighted(runes []rune, ranges []matchRange) string {
+	var builder strings.Builder
+	cursor := 0
+	for _, r := range ranges {
+		builder.WriteString(html.EscapeString(string(runes[cursor:r.start])))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(string(runes[r.start:r.end])))
+		builder.WriteString("</mark>")
+		cursor = r.end
+	}
+	builder.WriteString(html.EscapeString(string(runes[cursor:])))
+	return builder.String()
+}
+
+// buildSnippet 截取首个命中附近的正文片段并高亮；正文无命中时退回摘要开头
+func buildSnippet(plain, excerpt string, terms [][]rune) string {
+	runes := []rune(plain)
+	ranges := findMatches(runes, terms)
+	if len(ranges) == 0 {
+		source := []rune(strings.TrimSpace(excerpt))
+		if len(source) == 0 {
+			source = runes
+		}
+		if len(source) > snippetRunes {
+			return highlightText(string(source[:snippetRunes]), terms) + "…"
+		}
+		return highlightText(string(source), terms)
+	}
+
+	start := ranges[0].start - snippetLeadingRune
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetRunes
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	window := make([]matchRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.start >= start && r.end <= end {
+			window = append(window, matchRange{start: r.start - start, end: r.end - start})
+		}
+	}
+
+	snippet := renderHighlighted(runes[start:end], window)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+var (
+	mdCodeFencePattern = regexp.MustCompile("(?m)^\\s*(```|~~~).*$")
+	mdImagePattern     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLinkPattern      = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdHTMLTagPattern   = regexp.MustCompile(`<[^>]+>`)
+	mdLinePrefix       = regexp.MustCompile(`(?m)^\s{0,3}(#{1,6}\s+|>\s?|[-*+]\s+|\d+\.\s+)`)
+	mdEmphasisPattern  = regexp.MustCompile("[*_~`]+")
+)
+
+// markdownToPlainText 粗略去除 Markdown 标记，用于生成检索片段
+func markdownToPlainText(content string) string {
+	text := mdCodeFencePattern.ReplaceAllString(content, "")
+	text = mdImagePattern.ReplaceAllString(text, "$1")
+	text = mdLinkPattern.ReplaceAllString(text, "$1")
+	text = mdHTMLTagPattern.ReplaceAllString(text, "")
+	text = mdLinePrefix.ReplaceAllString(text, "")
+	text = mdEmphasisPattern.ReplaceAllString(text, "")
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -14,8 +14,16 @@ import (
 )
 
 // 创建文章，并分配分类和标签
-func CreatePost(post *models.Post, categoryIDs, tagIDs []uint64) error {
-	err := dao.CreatePost(post)
+func CreatePost(post *models.Post, categoryIDs, tagIDs []uint64, writes ...ContentWrite) error {
+	if post.Lang == "" {
+		post.Lang = DefaultLang()
+	}
+	err := dao.RunContentTx(func(t *dao.ContentTx) error {
+		if err := runContentWrites(t, writes); err != nil {
+			return err
+		}
+		return t.CreatePost(post)
+	})
 	if err != nil {
 		return err
 	}
@@ -30,6 +38,8 @@ func CreatePost(post *models.Post, categoryIDs, tagIDs []uint64) error {
 			return err
 		}
 	}
+	recountPostTaxonomies([]uint64{post.ID}, nil, nil)
+	notifyPostsChanged(post.ID)
 	return nil
 }
 
@@ -38,9 +48,37 @@ func GetPostByID(id uint64) (*models.Post, error) {
 	return dao.GetPostByID(id)
 }
 
-// 更新文章
-func UpdatePost(post *models.Post) error {
-	return dao.UpdatePost(post)
+// ContentWrite 与内容保存在同一事务中执行的附加写入，在保存前执行
+type ContentWrite func(t *dao.ContentTx) error
+
+// 更新文章；writes 与文章保存一起提交，保存失败时一并回滚
+func UpdatePost(post *models.Post, writes ...ContentWrite) error {
+	oldSlug, _ := dao.GetPostSlug(post.ID)
+	err := dao.RunContentTx(func(t *dao.ContentTx) error {
+		if err := runContentWrites(t, writes); err != nil {
+			return err
+		}
+		return t.UpdatePost(post)
+	})
+	if err != nil {
+		return err
+	}
+	recordPostSlugChange(post.ID, oldSlug, post.Slug)
+	recountPostTaxonomies([]uint64{post.ID}, nil, nil)
+	notifyPostsChanged(post.ID)
+	return nil
+}
+
+func runContentWrites(t *dao.ContentTx, writes []ContentWrite) error {
+	for _, write := range writes {
+		if write == nil {
+			continue
+		}
+		if err := write(t); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // 查询文章列表
@@ -49,8 +87,8 @@ func ListPosts() ([]models.Post, error) {
 }
 
 // 查询文章列表带参数
-func ListPostsWithParams(page, pageSize int, q, sort, category, tag string, status string) ([]models.PostWithRelations, error) {
-	posts, err := dao.ListPostsWithParams(page, pageSize, q, sort, category, tag, status)
+func ListPostsWithParams(page, pageSize int, q, sort, category, tag, author, status, visibility, lang string) ([]models.PostWithRelations, error) {
+	posts, err := dao.ListPostsWithParams(page, pageSize, q, sort, category, tag, author, status, visibility, lang)
 	if err != nil {
 		return nil, err
 	}
@@ -75,10 +113,9 @@ func stringToUint64Slice(s string) []uint64 {
 
 // 删除
 func DeletePost(id uint64) error {
-	// 先删除关联的分类和标签
-	dao.DeletePostCategories(id)
-	dao.DeletePostTags(id)
-	return dao.DeletePost(id)
+	// 删除只移入回收站，关联数据在彻底删除时一并清理
+	_, err := TrashPosts([]uint64{id})
+	return err
 }
 
 // 生成slug（如果未提供）
@@ -103,16 +140,16 @@ func GenerateSlug(title string) string {
 	return slug
 }
 
-// 确保slug唯一（如果已存在则添加数字后缀）
+// 确保slug唯一（如果已存在则添加数字后缀）；其他文章的历史slug同样视为已占用，保证旧链接仍能跳转
 func EnsureUniqueSlug(slug string, excludeID uint64) string {
-	if !dao.PostSlugExists(slug, excludeID) {
+	if !postSlugTaken(slug, excludeID) {
 		return slug
 	}
 
 	// 如果已存在，添加数字后缀
 	for i := 1; i < 1000; i++ {
 		newSlug := fmt.Sprintf("%s-%d", slug, i)
-		if !dao.PostSlugExists(newSlug, excludeID) {
+		if !postSlugTaken(newSlug, excludeID) {
 			return newSlug
 		}
 	}
@@ -123,6 +160,10 @@ func EnsureUniqueSlug(slug string, excludeID uint64) string {
 
 // 更新文章的分类和标签
 func UpdatePostCategoriesAndTags(postID uint64, categoryIDs, tagIDs []uint64) error {
+	// 记录旧的关联，被移除的分类标签同样需要重算文章数
+	oldCategoryIDs, _ := dao.GetPostCategoryIDs(postID)
+	oldTagIDs, _ := dao.GetPostTagIDs(postID)
+
 	// 删除旧的关联
 	dao.DeletePostCategories(postID)
 	dao.DeletePostTags(postID)
@@ -138,6 +179,8 @@ func UpdatePostCategoriesAndTags(postID uint64, categoryIDs, tagIDs []uint64) er
 			return err
 		}
 	}
+	recountPostTaxonomies([]uint64{postID}, oldCategoryIDs, oldTagIDs)
+	notifyPostsChanged(postID)
 	return nil
 }
 
@@ -175,6 +218,11 @@ func GetPostWithFullRelations(id uint64) (*models.Post, []models.Category, []mod
 		tags = []models.Tag{}
 	}
 
+	// 作者公开信息（作者账号已删除时不返回）
+	if authors, err := dao.GetAuthorSummaries([]uint64{post.AuthorID}); err == nil {
+		post.Author = authors[post.AuthorID]
+	}
+
 	// 确保返回的不是nil
 	if categories == nil {
 		categories = []models.Category{}
@@ -183,6 +231,11 @@ func GetPostWithFullRelations(id uint64) (*models.Post, []models.Category, []mod
 		tags = []models.Tag{}
 	}
 
+	// 系列导航（查询失败不影响详情）
+	if nav, err := GetPostSeriesNav(id); err == nil {
+		post.Series = nav
+	}
+
 	return post, categories, tags, nil
 }
 
@@ -205,7 +258,7 @@ type PostListResponse struct {
 }
 
 // 查询文章列表带分页
-func ListPostsWithPagination(page, pageSize int, q, sort, category, tag, status string) (*PostListResponse, error) {
+func ListPostsWithPagination(page, pageSize int, q, sort, category, tag, author, status, visibility, lang string) (*PostListResponse, error) {
 	// 参数验证和默认值
 	if page < 1 {
 		page = 1
@@ -218,13 +271,13 @@ func ListPostsWithPagination(page, pageSize int, q, sort, category, tag, status
 	}
 
 	// 获取总数
-	total, err := dao.CountPosts(q, sort, category, tag, status)
+	total, err := dao.CountPosts(q, sort, category, tag, author, status, visibility, lang)
 	if err != nil {
 		return nil, err
 	}
 
 	// 获取文章列表
-	posts, err := dao.ListPostsWithParams(page, pageSize, q, sort, category, tag, status)
+	posts, err := dao.ListPostsWithParams(page, pageSize, q, sort, category, tag, author, status, visibility, lang)
 	if err != nil {
 		return nil, err
 	}
@@ -240,3 +293,50 @@ func ListPostsWithPagination(page, pageSize int, q, sort, category, tag, status
 		TotalPages: totalPages,
 	}, nil
 }
+
+// ApplyPostStatus 根据目标状态维护文章的发布时间。
+// publishAt 仅在定时发布时使用，且必须晚于当前时间；已是定时状态的文章也可以只调整 publishAt。
+func ApplyPostStatus(post *models.Post, status string, publishAt *time.Time) error {
+	now := time.Now()
+	if status == "" {
+		if publishAt != nil && post.Status == StatusScheduled {
+			if !publishAt.After(now) {
+				return ErrInvalidSchedule
+			}
+			post.PublishedAt = publishAt
+		}
+		return nil
+	}
+
+	oldStatus := post.Status
+	post.Status = status
+	if oldStatus == StatusTrash && status != StatusTrash {
+		post.TrashedAt = nil
+		post.TrashedFrom = ""
+	}
+	switch {
+	case status == StatusTrash:
+		// 移入回收站时保留发布时间，恢复后沿用
+		if oldStatus != StatusTrash {
+			post.TrashedAt = &now
+			post.TrashedFrom = oldStatus
+		}
+	case status == StatusScheduled:
+		if publishAt == nil {
+			publishAt = post.PublishedAt
+		}
+		if publishAt == nil || !publishAt.After(now) {
+			return ErrInvalidSchedule
+		}
+		post.PublishedAt = publishAt
+	case status == "published":
+		// 从非发布状态变为发布时设置发布时间；定时文章被提前发布时以当前时间为准
+		if post.PublishedAt == nil || oldStatus == StatusScheduled {
+			post.PublishedAt = &now
+		}
+	case oldStatus == "published" || oldStatus == StatusScheduled:
+		// 从发布或定时状态改为其他状态，清空发布时间
+		post.PublishedAt = nil
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"api/internal/modules/content/models"
+)
+
+func TestApplyPostStatus(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	cases := []struct {
+		name      string
+		post      models.Post
+		status    string
+		publishAt *time.Time
+		wantErr   error
+		check     func(t *testing.T, post *models.Post)
+	}{
+		{
+			name:   "草稿发布时设置发布时间",
+			post:   models.Post{Status: "draft"},
+			status: "published",
+			check: func(t *testing.T, post *models.Post) {
+				if post.PublishedAt == nil || post.PublishedAt.Before(now) {
+					t.Fatalf("published_at = %v, want now", post.PublishedAt)
+				}
+			},
+		},
+		{
+			name:   "重新发布保留原发布时间",
+			post:   models.Post{Status: "draft", PublishedAt: &past},
+			status: "published",
+			check: func(t *testing.T, post *models.Post) {
+				if post.PublishedAt == nil || !post.PublishedAt.Equal(past) {
+					t.Fatalf("published_at = %v, want %v", post.PublishedAt, past)
+				}
+			},
+		},
+		{
+			name:      "定时发布需要未来时间",
+			post:      models.Post{Status: "draft"},
+			status:    StatusScheduled,
+			publishAt: &past,
+			wantErr:   ErrInvalidSchedule,
+		},
+		{
+			name:    "定时发布缺少时间",
+			post:    models.Post{Status: "draft"},
+			status:  StatusScheduled,
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name:      "定时发布",
+			post:      models.Post{Status: "draft"},
+			status:    StatusScheduled,
+			publishAt: &future,
+			check: func(t *testing.T, post *models.Post) {
+				if post.Status != StatusScheduled || post.PublishedAt == nil || !post.PublishedAt.Equal(future) {
+					t.Fatalf("status = %s, published_at = %v", post.Status, post.PublishedAt)
+				}
+			},
+		},
+		{
+			name:   "定时文章提前发布以当前时间为准",
+			post:   models.Post{Status: StatusScheduled, PublishedAt: &future},
+			status: "published",
+			check: func(t *testing.T, post *models.Post) {
+				if post.PublishedAt == nil || post.PublishedAt.After(time.Now()) {
+					t.Fatalf("published_at = %v, want now", post.PublishedAt)
+				}
+			},
+		},
+		{
+			name:   "撤回定时发布清空发布时间",
+			post:   models.Post{Status: StatusScheduled, PublishedAt: &future},
+			status: "draft",
+			check: func(t *testing.T, post *models.Post) {
+				if post.PublishedAt != nil {
+					t.Fatalf("published_at = %v, want nil", post.PublishedAt)
+				}
+			},
+		},
+		{
+			name:   "移入回收站记录原状态并保留发布时间",
+			post:   models.Post{Status: "published", PublishedAt: &past},
+			status: StatusTrash,
+			check: func(t *testing.T, post *models.Post) {
+				if post.TrashedFrom != "published" || post.TrashedAt == nil || post.PublishedAt == nil {
+					t.Fatalf("trashed_from = %q, trashed_at = %v, published_at = %v", post.TrashedFrom, post.TrashedAt, post.PublishedAt)
+				}
+			},
+		},
+		{
+			name:   "移出回收站清除回收站标记",
+			post:   models.Post{Status: StatusTrash, TrashedAt: &past, TrashedFrom: "draft"},
+			status: "draft",
+			check: func(t *testing.T, post *models.Post) {
+				if post.TrashedAt != nil || post.TrashedFrom != "" {
+					t.Fatalf("trashed_at = %v, trashed_from = %q", post.TrashedAt, post.TrashedFrom)
+				}
+			},
+		},
+		{
+			name:      "不改状态时只能调整定时发布时间",
+			post:      models.Post{Status: "published", PublishedAt: &past},
+			publishAt: &future,
+			check: func(t *testing.T, post *models.Post) {
+				if !post.PublishedAt.Equal(past) {
+					t.Fatalf("published_at = %v, want %v", post.PublishedAt, past)
+				}
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			post := tc.post
+			err := ApplyPostStatus(&post, tc.status, tc.publishAt)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ApplyPostStatus() error = %v, want %v", err, tc.wantErr)
+			}
+			if err == nil && tc.check != nil {
+				tc.check(t, &post)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// postSlugStore 文章当前slug与历史slug的读写，默认使用数据库，测试中替换为内存实现
+type postSlugStore interface {
+	PostBySlug(slug string) (*models.Post, error)
+	PostByID(id uint64) (*models.Post, error)
+	History(slug string) (*models.PostSlugHistory, error)
+	SaveHistory(postID uint64, slug string) error
+	DeleteHistory(slug string) error
+	SlugExists(slug string, excludeID uint64) bool
+	SlugInHistory(slug string, excludeID uint64) bool
+}
+
+type daoPostSlugStore struct{}
+
+func (daoPostSlugStore) PostBySlug(slug string) (*models.Post, error) { return dao.GetPostBySlug(slug) }
+func (daoPostSlugStore) PostByID(id uint64) (*models.Post, error)     { return dao.GetPostByID(id) }
+func (daoPostSlugStore) History(slug string) (*models.PostSlugHistory, error) {
+	return dao.GetPostSlugHistory(slug)
+}
+func (daoPostSlugStore) SaveHistory(postID uint64, slug string) error {
+	return dao.SavePostSlugHistory(postID, slug)
+}
+func (daoPostSlugStore) DeleteHistory(slug string) error { return dao.DeletePostSlugHistory(slug) }
+func (daoPostSlugStore) SlugExists(slug string, excludeID uint64) bool {
+	return dao.PostSlugExists(slug, excludeID)
+}
+func (daoPostSlugStore) SlugInHistory(slug string, excludeID uint64) bool {
+	return dao.PostSlugInHistory(slug, excludeID)
+}
+
+var slugStore postSlugStore = daoPostSlugStore{}
+
+// ResolvePostSlug 通过slug查找文章；命中历史slug时 redirected 为 true，调用方应提示跳转到 post.Slug
+func ResolvePostSlug(slug string) (post *models.Post, redirected bool, err error) {
+	post, err = slugStore.PostBySlug(slug)
+	if err == nil {
+		return post, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	record, err := slugStore.History(slug)
+	if err != nil {
+		return nil, false, err
+	}
+	post, err = slugStore.PostByID(record.PostID)
+	if err != nil {
+		return nil, false, err
+	}
+	return post, true, nil
+}
+
+// ListPostSlugHistory 查询文章的历史slug
+func ListPostSlugHistory(postID uint64) ([]models.PostSlugHistory, error) {
+	return dao.ListPostSlugHistory(postID)
+}
+
+// recordPostSlugChange slug变更后记录旧slug；新slug若曾是历史slug则移除，避免自我跳转
+func recordPostSlugChange(postID uint64, oldSlug, newSlug string) {
+	if oldSlug == "" || oldSlug == newSlug {
+		return
+	}
+	if err := slugStore.SaveHistory(postID, oldSlug); err != nil {
+		fmt.Printf("[post] save slug history error: %v\n", err)
+	}
+	if err := slugStore.DeleteHistory(newSlug); err != nil {
+		fmt.Printf("[post] delete slug history error: %v\n", err)
+	}
+}
+
+func postSlugTaken(slug string, excludeID uint64) bool {
+	return slugStore.SlugExists(slug, excludeID) || slugStore.SlugInHistory(slug, excludeID)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"api/internal/modules/content/models"
+
+	"gorm.io/gorm"
+)
+
+// memSlugStore 内存中的文章与历史slug，语义与数据库一致：历史slug唯一，重复保存时归属最新的文章
+type memSlugStore struct {
+	posts   map[uint64]*models.Post
+	history map[string]uint64
+}
+
+func newMemSlugStore(posts ...*models.Post) *memSlugStore {
+	s := &memSlugStore{posts: make(map[uint64]*models.Post), history: make(map[string]uint64)}
+	for _, post := range posts {
+		s.posts[post.ID] = post
+	}
+	return s
+}
+
+func (s *memSlugStore) PostBySlug(slug string) (*models.Post, error) {
+	for _, post := range s.posts {
+		if post.Slug == slug {
+			return post, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *memSlugStore) PostByID(id uint64) (*models.Post, error) {
+	if post, ok := s.posts[id]; ok {
+		return post, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *memSlugStore) History(slug string) (*models.PostSlugHistory, error) {
+	if id, ok := s.history[slug]; ok {
+		return &models.PostSlugHistory{PostID: id, Slug: slug}, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *memSlugStore) SaveHistory(postID uint64, slug string) error {
+	s.history[slug] = postID
+	return nil
+}
+
+func (s *memSlugStore) DeleteHistory(slug string) error {
+	delete(s.history, slug)
+	return nil
+}
+
+func (s *memSlugStore) SlugExists(slug string, excludeID uint64) bool {
+	for _, post := range s.posts {
+		if post.Slug == slug && post.ID != excludeID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *memSlugStore) SlugInHistory(slug string, excludeID uint64) bool {
+	id, ok := s.history[slug]
+	return ok && id != excludeID
+}
+
+// rename 模拟后台修改slug：与 UpdatePost 一样先保证唯一，再记录旧slug
+func (s *memSlugStore) rename(post *models.Post, slug string) {
+	old := post.Slug
+	post.Slug = EnsureUniqueSlug(slug, post.ID)
+	recordPostSlugChange(post.ID, old, post.Slug)
+}
+
+func useSlugStore(t *testing.T, store postSlugStore) {
+	t.Helper()
+	prev := slugStore
+	slugStore = store
+	t.Cleanup(func() { slugStore = prev })
+}
+
+func assertResolve(t *testing.T, slug string, wantID uint64, wantSlug string, wantRedirect bool) {
+	t.Helper()
+	post, redirected, err := ResolvePostSlug(slug)
+	if err != nil {
+		t.Fatalf("ResolvePostSlug(%q) error = %v", slug, err)
+	}
+	if post.ID != wantID || post.Slug != wantSlug || redirected != wantRedirect {
+		t.Fatalf("ResolvePostSlug(%q) = post %d (%q), redirected %v; want post %d (%q), redirected %v",
+			slug, post.ID, post.Slug, redirected, wantID, wantSlug, wantRedirect)
+	}
+}
+
+func TestResolvePostSlugHistory(t *testing.T) {
+	post := &models.Post{ID: 1, Slug: "hello"}
+	store := newMemSlugStore(post)
+	useSlugStore(t, store)
+
+	assertResolve(t, "hello", 1, "hello", false)
+
+	// 多次改名后，每个旧slug都直接跳转到当前slug，而不是逐级跳转
+	store.rename(post, "hello-go")
+	store.rename(post, "hello-go-1-22")
+	assertResolve(t, "hello", 1, "hello-go-1-22", true)
+	assertResolve(t, "hello-go", 1, "hello-go-1-22", true)
+	assertResolve(t, "hello-go-1-22", 1, "hello-go-1-22", false)
+
+	// 改回曾用过的slug时移除该历史记录，避免跳转到自身
+	store.rename(post, "hello")
+	if _, ok := store.history["hello"]; ok {
+		t.Fatal("current slug is still recorded as history")
+	}
+	assertResolve(t, "hello", 1, "hello", false)
+	assertResolve(t, "hello-go-1-22", 1, "hello", true)
+
+	// 未改名时不记录历史
+	store.rename(post, "hello")
+	if len(store.history) != 2 {
+		t.Fatalf("history = %v", store.history)
+	}
+
+	if _, _, err := ResolvePostSlug("missing"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("ResolvePostSlug(missing) error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestEnsureUniqueSlugKeepsOldLinks(t *testing.T) {
+	first := &models.Post{ID: 1, Slug: "intro"}
+	second := &models.Post{ID: 2, Slug: "draft-2"}
+	store := newMemSlugStore(first, second)
+	useSlugStore(t, store)
+
+	store.rename(first, "introduction")
+
+	// 其他文章不能占用旧slug，否则旧链接会指向错误的文章
+	store.rename(second, "intro")
+	if second.Slug != "intro-1" {
+		t.Fatalf("second post slug = %q, want intro-1", second.Slug)
+	}
+	assertResolve(t, "intro", 1, "introduction", true)
+	assertResolve(t, "intro-1", 2, "intro-1", false)
+	assertResolve(t, "draft-2", 2, "intro-1", true)
+
+	// 文章自己可以改回自己的旧slug
+	store.rename(first, "intro")
+	if first.Slug != "intro" {
+		t.Fatalf("first post slug = %q, want intro", first.Slug)
+	}
+	assertResolve(t, "introduction", 1, "intro", true)
+
+	// 当前slug被占用时同样加后缀
+	store.rename(second, "intro")
+	if second.Slug != "intro-1" {
+		t.Fatalf("second post slug = %q, want intro-1", second.Slug)
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	VisibilityPublic   = "public"
+	VisibilityPrivate  = "private"
+	VisibilityPassword = "password"
+)
+
+// 列表、检索的可见性范围
+const (
+	PostScopeAll    = dao.PostScopeAll
+	PostScopeListed = dao.PostScopeListed
+	PostScopePublic = dao.PostScopePublic
+)
+
+// PostAccess 访客对文章的访问结果
+type PostAccess int
+
+const (
+	PostAccessAllowed  PostAccess = iota // 可以查看全文
+	PostAccessLocked                     // 密码文章，需要先解锁
+	PostAccessNotFound                   // 私密文章，对访客视为不存在
+)
+
+var (
+	ErrInvalidVisibility  = errors.New("visibility 只能是 public、private 或 password")
+	ErrPostPasswordNeeded = errors.New("密码可见的文章需要设置访问密码")
+	ErrPostPasswordWrong  = errors.New("访问密码错误")
+)
+
+// ApplyPostVisibility 设置文章可见性；password 非空时重新设置访问密码（以 bcrypt 哈希保存）。
+// 切换为非密码可见时清空已保存的密码。
+func ApplyPostVisibility(post *models.Post, visibility, password string) error {
+	if visibility == "" {
+		visibility = post.Visibility
+	}
+	if visibility == "" {
+		visibility = VisibilityPublic
+	}
+
+	switch visibility {
+	case VisibilityPublic, VisibilityPrivate:
+		post.Visibility = visibility
+		post.Password = ""
+		return nil
+	case VisibilityPassword:
+	default:
+		return ErrInvalidVisibility
+	}
+
+	if password == "" {
+		if post.Password == "" {
+			return ErrPostPasswordNeeded
+		}
+		post.Visibility = visibility
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	post.Visibility = visibility
+	post.Password = string(hash)
+	return nil
+}
+
+// VerifyPostPassword 校验文章访问密码；兼容早期以明文保存的密码
+func VerifyPostPassword(post *models.Post, password string) bool {
+	if post.Visibility != VisibilityPassword || post.Password == "" || password == "" {
+		return false
+	}
+	if strings.HasPrefix(post.Password, "$2") {
+		return bcrypt.CompareHashAndPassword([]byte(post.Password), []byte(password)) == nil
+	}
+	return subtle.ConstantTimeCompare([]byte(post.Password), []byte(password)) == 1
+}
+
+// UnlockPost 校验密码并签发访问令牌
+func UnlockPost(post *models.Post, password string) (string, time.Time, error) {
+	if !VerifyPostPassword(post, password) {
+		return "", time.Time{}, ErrPostPasswordWrong
+	}
+	token, expiresAt := IssuePostAccessToken(post)
+	return token, expiresAt, nil
+}
+
+// IssuePostAccessToken 签发文章访问令牌，格式为 <文章ID>.<过期时间戳>.<签名>。
+// 签名包含当前密码哈希，修改密码后旧令牌随即失效。
+func IssuePostAccessToken(post *models.Post) (string, time.Time) {
+	ttl := config.Load().PostAccessTokenTTL
+	if ttl <= 0 {
+		ttl = 2 * time.Hour
+	}
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	payload := strconv.FormatUint(post.ID, 10) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + signPostAccess(payload, post.Password), expiresAt
+}
+
+// VerifyPostAccessToken 校验访问令牌是否属于该文章且未过期
+func VerifyPostAccessToken(post *models.Post, token string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != strconv.FormatUint(post.ID, 10) {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	expected := signPostAccess(parts[0]+"."+parts[1], post.Password)
+	return hmac.Equal([]byte(parts[2]), []byte(expected))
+}
+
+// CheckPostAccess 判断访客能否查看文章：回收站中的文章对所有人不可见；作者本人与管理员不受限制；
+// 未发布（草稿、待审核、未到时间的定时发布）的文章只有持有效预览签名（preview）时才能查看，
+// 预览签名只放行发布状态，私密与密码校验照常进行
+func CheckPostAccess(post *models.Post, viewerID uint64, viewerRole, token string, preview bool) PostAccess {
+	if post.Status == StatusTrash {
+		return PostAccessNotFound
+	}
+	if viewerRole == "admin" || (viewerID != 0 && viewerID == post.AuthorID) {
+		return PostAccessAllowed
+	}
+	if post.Status != "published" && !preview {
+		return PostAccessNotFound
+	}
+	switch post.Visibility {
+	case VisibilityPrivate:
+		return PostAccessNotFound
+	case VisibilityPassword:
+		if token != "" && VerifyPostAccessToken(post, token) {
+			return PostAccessAllowed
+		}
+		return PostAccessLocked
+	default:
+		return PostAccessAllowed
+	}
+}
+
+// CheckPageAccess 未发布的页面只对管理员或持有效预览签名的访客可见
+func CheckPageAccess(page *models.Page, viewerRole string, preview bool) bool {
+	return page.Status == "published" || viewerRole == "admin" || preview
+}
+
+// MaskLockedPosts 列表中的密码文章不返回正文与摘要
+func MaskLockedPosts(posts []models.PostWithRelations) {
+	for i := range posts {
+		if posts[i].Visibility == VisibilityPassword {
+			posts[i].Content = ""
+			posts[i].Excerpt = ""
+		}
+	}
+}
+
+func signPostAccess(payload, passwordHash string) string {
+	mac := hmac.New(sha256.New, signingSecret("POST_ACCESS_SECRET", config.Load().PostAccessSecret))
+	mac.Write([]byte(payload))
+	mac.Write([]byte{0})
+	mac.Write([]byte(passwordHash))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/modules/content/models"
+)
+
+func TestApplyPostVisibility(t *testing.T) {
+	const storedHash = "$2a$10$stored"
+	cases := []struct {
+		name       string
+		post       models.Post
+		visibility string
+		password   string
+		want       string
+		wantErr    error
+		keepHash   bool // 仍保留原来的密码哈希
+	}{
+		{"默认公开", models.Post{}, "", "", VisibilityPublic, nil, false},
+		{"未指定时保持原可见性", models.Post{Visibility: VisibilityPrivate}, "", "", VisibilityPrivate, nil, false},
+		{"改为公开清空密码", models.Post{Visibility: VisibilityPassword, Password: storedHash}, VisibilityPublic, "", VisibilityPublic, nil, false},
+		{"改为私密清空密码", models.Post{Visibility: VisibilityPassword, Password: storedHash}, VisibilityPrivate, "", VisibilityPrivate, nil, false},
+		{"密码可见缺少密码", models.Post{}, VisibilityPassword, "", "", ErrPostPasswordNeeded, false},
+		{"留空保留原密码", models.Post{Visibility: VisibilityPassword, Password: storedHash}, VisibilityPassword, "", VisibilityPassword, nil, true},
+		{"无效的可见性", models.Post{}, "friends", "", "", ErrInvalidVisibility, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			post := tc.post
+			err := ApplyPostVisibility(&post, tc.visibility, tc.password)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ApplyPostVisibility() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if post.Visibility != tc.want {
+				t.Fatalf("visibility = %q, want %q", post.Visibility, tc.want)
+			}
+			if tc.keepHash != (post.Password == storedHash) || (!tc.keepHash && post.Password != "") {
+				t.Fatalf("password = %q", post.Password)
+			}
+		})
+	}
+}
+
+func TestVerifyPostPassword(t *testing.T) {
+	post := &models.Post{}
+	if err := ApplyPostVisibility(post, VisibilityPassword, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(post.Password, "$2") || post.Password == "secret" {
+		t.Fatalf("password is not stored as a bcrypt hash: %q", post.Password)
+	}
+
+	cases := []struct {
+		name     string
+		post     models.Post
+		password string
+		want     bool
+	}{
+		{"正确密码", *post, "secret", true},
+		{"错误密码", *post, "Secret", false},
+		{"空密码", *post, "", false},
+		{"兼容明文密码", models.Post{Visibility: VisibilityPassword, Password: "legacy"}, "legacy", true},
+		{"明文密码错误", models.Post{Visibility: VisibilityPassword, Password: "legacy"}, "legacy2", false},
+		{"非密码可见的文章", models.Post{Visibility: VisibilityPublic, Password: "legacy"}, "legacy", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := VerifyPostPassword(&tc.post, tc.password); got != tc.want {
+				t.Fatalf("VerifyPostPassword() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPostAccessToken(t *testing.T) {
+	post := &models.Post{ID: 9, Visibility: VisibilityPassword, Password: "$2a$10$hash-v1"}
+	token, expiresAt := IssuePostAccessToken(post)
+	if !expiresAt.After(time.Now()) {
+		t.Fatalf("expiresAt = %v, want future", expiresAt)
+	}
+
+	parts := strings.Split(token, ".")
+	tampered := "A" + parts[2][1:]
+	if tampered == parts[2] {
+		tampered = "B" + parts[2][1:]
+	}
+	expiredPayload := "9." + strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	cases := []struct {
+		name  string
+		post  models.Post
+		token string
+		want  bool
+	}{
+		{"有效令牌", *post, token, true},
+		{"其他文章", models.Post{ID: 10, Password: post.Password}, token, false},
+		{"密码已修改", models.Post{ID: 9, Password: "$2a$10$hash-v2"}, token, false},
+		{"签名被篡改", *post, parts[0] + "." + parts[1] + "." + tampered, false},
+		{"延长过期时间", *post, parts[0] + "." + strconv.FormatInt(time.Now().Add(365*24*time.Hour).Unix(), 10) + "." + parts[2], false},
+		{"已过期", *post, expiredPayload + "." + signPostAccess(expiredPayload, post.Password), false},
+		{"格式错误", *post, "9." + parts[1], false},
+		{"空令牌", *post, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := VerifyPostAccessToken(&tc.post, tc.token); got != tc.want {
+				t.Fatalf("VerifyPostAccessToken() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckPostAccess(t *testing.T) {
+	const authorID = 7
+	cases := []struct {
+		name    string
+		post    models.Post
+		viewer  uint64
+		role    string
+		preview bool
+		want    PostAccess
+	}{
+		{"公开文章", models.Post{Status: "published", Visibility: VisibilityPublic}, 0, "", false, PostAccessAllowed},
+		{"私密文章对访客不可见", models.Post{Status: "published", Visibility: VisibilityPrivate}, 0, "", false, PostAccessNotFound},
+		{"私密文章对作者可见", models.Post{Status: "published", Visibility: VisibilityPrivate}, authorID, "author", false, PostAccessAllowed},
+		{"密码文章未解锁", models.Post{Status: "published", Visibility: VisibilityPassword}, 0, "", false, PostAccessLocked},
+		{"草稿对访客不可见", models.Post{Status: "draft", Visibility: VisibilityPublic}, 0, "", false, PostAccessNotFound},
+		{"草稿对其他作者不可见", models.Post{Status: "draft", Visibility: VisibilityPublic}, authorID + 1, "author", false, PostAccessNotFound},
+		{"草稿对作者可见", models.Post{Status: "draft", Visibility: VisibilityPublic}, authorID, "author", false, PostAccessAllowed},
+		{"草稿对管理员可见", models.Post{Status: "draft", Visibility: VisibilityPublic}, 1, "admin", false, PostAccessAllowed},
+		{"草稿持预览签名可见", models.Post{Status: "draft", Visibility: VisibilityPublic}, 0, "", true, PostAccessAllowed},
+		{"私密草稿持预览签名仍不可见", models.Post{Status: "draft", Visibility: VisibilityPrivate}, 0, "", true, PostAccessNotFound},
+		{"私密文章持预览签名仍不可见", models.Post{Status: "published", Visibility: VisibilityPrivate}, 0, "", true, PostAccessNotFound},
+		{"密码文章持预览签名仍需解锁", models.Post{Status: "published", Visibility: VisibilityPassword}, 0, "", true, PostAccessLocked},
+		{"待审核对访客不可见", models.Post{Status: "pending", Visibility: VisibilityPublic}, 0, "", false, PostAccessNotFound},
+		{"定时发布对访客不可见", models.Post{Status: StatusScheduled, Visibility: VisibilityPublic}, 0, "", false, PostAccessNotFound},
+		{"定时发布对作者可见", models.Post{Status: StatusScheduled, Visibility: VisibilityPublic}, authorID, "author", false, PostAccessAllowed},
+		{"回收站对访客不可见", models.Post{Status: StatusTrash, Visibility: VisibilityPublic}, 0, "", false, PostAccessNotFound},
+		{"回收站对作者不可见", models.Post{Status: StatusTrash, Visibility: VisibilityPublic}, authorID, "author", false, PostAccessNotFound},
+		{"回收站对管理员不可见", models.Post{Status: StatusTrash, Visibility: VisibilityPublic}, 1, "admin", false, PostAccessNotFound},
+		{"回收站持预览签名不可见", models.Post{Status: StatusTrash, Visibility: VisibilityPublic}, 0, "", true, PostAccessNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			post := tc.post
+			post.ID = 1
+			post.AuthorID = authorID
+			if got := CheckPostAccess(&post, tc.viewer, tc.role, "", tc.preview); got != tc.want {
+				t.Fatalf("CheckPostAccess() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
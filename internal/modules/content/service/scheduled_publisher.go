@@ -0,0 +1,151 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/platform/redisstore"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusScheduled = "scheduled"
+
+	defaultPublishInterval = time.Minute
+	publishBatchSize       = 100
+	publisherLockKey       = "content:scheduled_publisher:lock"
+)
+
+// ErrInvalidSchedule 定时发布缺少计划时间或计划时间不在未来
+var ErrInvalidSchedule = errors.New("定时发布需要提供晚于当前时间的 published_at")
+
+var publisherOnce sync.Once
+
+// releaseLockScript 仅当锁仍由自己持有时才删除，避免误删其他实例的锁
+const releaseLockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+
+// StartScheduledPublisher 启动定时发布任务，周期性地将到期的定时文章与动态改为已发布。
+// 多实例部署时通过 Redis 锁保证同一周期只有一个实例执行；发布语句本身也带状态条件，重复执行不会产生副作用。
+func StartScheduledPublisher(interval time.Duration) {
+	publisherOnce.Do(func() {
+		if interval <= 0 {
+			interval = defaultPublishInterval
+		}
+		go runScheduledPublisher(interval)
+	})
+}
+
+func runScheduledPublisher(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := publishDueContentWithLock(interval); err != nil {
+			fmt.Printf("[publisher] publish scheduled content error: %v\n", err)
+		}
+		<-ticker.C
+	}
+}
+
+func publishDueContentWithLock(interval time.Duration) error {
+	return runWithInstanceLock(publisherLockKey, interval, func() error {
+		posts, moments, err := PublishDueContent(time.Now())
+		if posts > 0 || moments > 0 {
+			fmt.Printf("[publisher] published %d posts, %d moments\n", posts, moments)
+		}
+		return err
+	})
+}
+
+// runWithInstanceLock 多实例部署时通过 Redis 锁保证同一周期只有一个实例执行 fn；
+// 未启用 Redis 视为单实例部署直接执行，Redis 不可用时跳过本轮
+func runWithInstanceLock(lockKey string, ttl time.Duration, fn func() error) error {
+	client, err := redisstore.GetClient()
+	if err != nil {
+		return fmt.Errorf("redis unavailable, skip this round: %w", err)
+	}
+	if client == nil {
+		return fn()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	token := uuid.New().String()
+	acquired, err := client.SetNX(ctx, lockKey, token, ttl).Result()
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+	defer func() {
+		releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer releaseCancel()
+		_ = client.Eval(releaseCtx, releaseLockScript, []string{lockKey}, token).Err()
+	}()
+	return fn()
+}
+
+// PublishDueContent 发布所有计划时间不晚于 now 的定时文章与动态，返回实际发布的数量
+func PublishDueContent(now time.Time) (int, int, error) {
+	postIDs, err := publishDuePosts(now)
+	if len(postIDs) > 0 {
+		recountPostTaxonomies(postIDs, nil, nil)
+		notifyPostsChanged(postIDs...)
+	}
+	if err != nil {
+		return len(postIDs), 0, err
+	}
+	momentCount, err := publishDueMoments(now)
+	return len(postIDs), momentCount, err
+}
+
+// publishDuePosts 返回实际发布的文章 ID
+func publishDuePosts(now time.Time) ([]uint64, error) {
+	var published []uint64
+	for {
+		ids, err := dao.ListDueScheduledPostIDs(now, publishBatchSize)
+		if err != nil {
+			return published, err
+		}
+		for _, id := range ids {
+			ok, err := dao.PublishScheduledPost(id)
+			if err != nil {
+				return published, err
+			}
+			if ok {
+				published = append(published, id)
+			}
+		}
+		if len(ids) < publishBatchSize {
+			return published, nil
+		}
+	}
+}
+
+func publishDueMoments(now time.Time) (int, error) {
+	published := 0
+	for {
+		ids, err := dao.ListDueScheduledMomentIDs(now, publishBatchSize)
+		if err != nil {
+			return published, err
+		}
+		for _, id := range ids {
+			ok, err := dao.PublishScheduledMoment(id)
+			if err != nil {
+				return published, err
+			}
+			if ok {
+				published++
+			}
+		}
+		if len(ids) < publishBatchSize {
+			return published, nil
+		}
+	}
+}
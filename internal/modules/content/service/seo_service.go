@@ -0,0 +1,235 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/models"
+	"api/internal/modules/media"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxMetaTitleRunes       = 200
+	maxMetaDescriptionRunes = 500
+	seoDescriptionRunes     = 160 // 自动生成描述时截取的长度
+)
+
+var (
+	ErrMetaTooLong         = errors.New("meta_title 不能超过 200 字，meta_description 不能超过 500 字")
+	ErrInvalidCanonicalURL = errors.New("canonical_url 必须是 http(s) 绝对地址或以 / 开头的站内路径")
+)
+
+// OpenGraph og:* 属性（键名不含 og: 前缀）
+type OpenGraph struct {
+	Type          string   `json:"type"`
+	Title         string   `json:"title"`
+	Description   string   `json:"description"`
+	URL           string   `json:"url"`
+	Image         string   `json:"image,omitempty"`
+	SiteName      string   `json:"site_name"`
+	PublishedTime string   `json:"published_time,omitempty"`
+	ModifiedTime  string   `json:"modified_time,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// SEOData 详情接口返回的 seo 块，前端可直接渲染为 <title>、<meta>、canonical 链接与 JSON-LD 脚本
+type SEOData struct {
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	CanonicalURL string                 `json:"canonical_url"`
+	Robots       string                 `json:"robots"`
+	Image        string                 `json:"image,omitempty"`
+	Alternates   []HrefLangAlternate    `json:"alternates,omitempty"` // 各语言版本（含自身），没有翻译时省略
+	OpenGraph    OpenGraph              `json:"open_graph"`
+	JSONLD       map[string]interface{} `json:"json_ld"`
+}
+
+// NormalizeSEOMeta 整理并校验后台提交的 SEO 字段，分享图片统一保存为完整URL
+func NormalizeSEOMeta(meta *models.SEOMeta) error {
+	meta.MetaTitle = strings.TrimSpace(meta.MetaTitle)
+	meta.MetaDescription = strings.TrimSpace(meta.MetaDescription)
+	meta.CanonicalURL = strings.TrimSpace(meta.CanonicalURL)
+	meta.OGImage = strings.TrimSpace(meta.OGImage)
+
+	if utf8.RuneCountInString(meta.MetaTitle) > maxMetaTitleRunes ||
+		utf8.RuneCountInString(meta.MetaDescription) > maxMetaDescriptionRunes {
+		return ErrMetaTooLong
+	}
+	if meta.CanonicalURL != "" && !strings.HasPrefix(meta.CanonicalURL, "/") {
+		u, err := url.Parse(meta.CanonicalURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidCanonicalURL
+		}
+	}
+	if meta.OGImage != "" {
+		meta.OGImage = media.GetFullFileURL(meta.OGImage)
+	}
+	return nil
+}
+
+// BuildPostSEO 生成文章详情的 seo 块；未填写的字段回退到标题、摘要与封面图
+func BuildPostSEO(post *models.Post, tags []models.Tag) *SEOData {
+	baseURL := strings.TrimRight(config.GetBaseURL(), "/")
+	siteName := config.Load().SiteTitle
+
+	seo := &SEOData{
+		Title:        firstNonEmpty(post.MetaTitle, post.Title),
+		Description:  firstNonEmpty(post.MetaDescription, contentSummary(post.Excerpt, post.Content, seoDescriptionRunes)),
+		CanonicalURL: canonicalURL(post.CanonicalURL, postURL(baseURL, post.Slug)),
+		Robots:       robotsDirective(post.NoIndex || post.Visibility != VisibilityPublic),
+		Alternates: hrefLangAlternates(post.Lang, postURL(baseURL, post.Slug), post.Translations, func(link models.TranslationLink) string {
+			return postURL(baseURL, link.Slug)
+		}),
+	}
+	if image := firstNonEmpty(post.OGImage, post.CoverImage); image != "" {
+		seo.Image = media.GetFullFileURL(image)
+	}
+
+	published := post.CreatedAt
+	if post.PublishedAt != nil {
+		published = *post.PublishedAt
+	}
+	keywords := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keywords = append(keywords, tag.Name)
+	}
+
+	seo.OpenGraph = OpenGraph{
+		Type:          "article",
+		Title:         seo.Title,
+		Description:   seo.Description,
+		URL:           seo.CanonicalURL,
+		Image:         seo.Image,
+		SiteName:      siteName,
+		PublishedTime: published.Format(time.RFC3339),
+		ModifiedTime:  post.UpdatedAt.Format(time.RFC3339),
+		Tags:          keywords,
+	}
+
+	ld := map[string]interface{}{
+		"@context":         "https://schema.org",
+		"@type":            "BlogPosting",
+		"headline":         post.Title,
+		"description":      seo.Description,
+		"url":              seo.CanonicalURL,
+		"mainEntityOfPage": map[string]interface{}{"@type": "WebPage", "@id": seo.CanonicalURL},
+		"datePublished":    published.Format(time.RFC3339),
+		"dateModified":     post.UpdatedAt.Format(time.RFC3339),
+		"publisher":        map[string]interface{}{"@type": "Organization", "name": siteName, "url": baseURL + "/"},
+	}
+	if seo.Image != "" {
+		ld["image"] = []string{seo.Image}
+	}
+	if post.Lang != "" {
+		ld["inLanguage"] = post.Lang
+	}
+	if len(keywords) > 0 {
+		ld["keywords"] = strings.Join(keywords, ", ")
+	}
+	// 作者信息由 GetPostWithFullRelations 填充，未填充时不输出
+	if author := post.Author; author != nil {
+		ld["author"] = map[string]interface{}{"@type": "Person", "name": firstNonEmpty(author.DisplayName, author.Username)}
+	}
+	seo.JSONLD = ld
+	return seo
+}
+
+// BuildLockedPostSEO 未解锁的密码文章只用标题与站点信息生成 seo 块，
+// 描述使用站点描述，避免摘要或截取的正文经 meta、Open Graph、JSON-LD 泄露
+func BuildLockedPostSEO(post *models.Post) *SEOData {
+	masked := *post
+	masked.Content, masked.Excerpt, masked.MetaDescription = "", "", ""
+	seo := BuildPostSEO(&masked, nil)
+	seo.Description = config.Load().SiteDescription
+	seo.OpenGraph.Description = seo.Description
+	seo.JSONLD["description"] = seo.Description
+	return seo
+}
+
+// BuildPageSEO 生成页面详情的 seo 块
+func BuildPageSEO(page *models.Page) *SEOData {
+	baseURL := strings.TrimRight(config.GetBaseURL(), "/")
+	siteName := config.Load().SiteTitle
+
+	seo := &SEOData{
+		Title:        firstNonEmpty(page.MetaTitle, page.Title),
+		Description:  firstNonEmpty(page.MetaDescription, contentSummary(page.Excerpt, page.Content, seoDescriptionRunes)),
+		CanonicalURL: canonicalURL(page.CanonicalURL, pageURL(baseURL, page.Slug)),
+		Robots:       robotsDirective(page.NoIndex),
+		Alternates: hrefLangAlternates(page.Lang, pageURL(baseURL, page.Slug), page.Translations, func(link models.TranslationLink) string {
+			return pageURL(baseURL, link.Slug)
+		}),
+	}
+	if page.OGImage != "" {
+		seo.Image = media.GetFullFileURL(page.OGImage)
+	}
+	seo.OpenGraph = OpenGraph{
+		Type:         "website",
+		Title:        seo.Title,
+		Description:  seo.Description,
+		URL:          seo.CanonicalURL,
+		Image:        seo.Image,
+		SiteName:     siteName,
+		ModifiedTime: page.UpdatedAt.Format(time.RFC3339),
+	}
+
+	ld := map[string]interface{}{
+		"@context":     "https://schema.org",
+		"@type":        "WebPage",
+		"name":         seo.Title,
+		"description":  seo.Description,
+		"url":          seo.CanonicalURL,
+		"dateModified": page.UpdatedAt.Format(time.RFC3339),
+		"isPartOf":     map[string]interface{}{"@type": "WebSite", "name": siteName, "url": baseURL + "/"},
+	}
+	if seo.Image != "" {
+		ld["image"] = []string{seo.Image}
+	}
+	if page.Lang != "" {
+		ld["inLanguage"] = page.Lang
+	}
+	seo.JSONLD = ld
+	return seo
+}
+
+// contentSummary 优先使用摘要，否则截取正文纯文本
+func contentSummary(excerpt, content string, maxRunes int) string {
+	if excerpt = strings.TrimSpace(excerpt); excerpt != "" {
+		return excerpt
+	}
+	runes := []rune(markdownToPlainText(content))
+	if len(runes) > maxRunes {
+		return string(runes[:maxRunes]) + "…"
+	}
+	return string(runes)
+}
+
+// canonicalURL 站内路径补全为绝对地址，未设置时使用默认前台地址
+func canonicalURL(configured, fallback string) string {
+	if configured == "" {
+		return fallback
+	}
+	if strings.HasPrefix(configured, "/") {
+		return strings.TrimRight(config.GetBaseURL(), "/") + configured
+	}
+	return configured
+}
+
+func robotsDirective(noindex bool) string {
+	if noindex {
+		return "noindex, nofollow"
+	}
+	return "index, follow"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
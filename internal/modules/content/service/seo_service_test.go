@@ -0,0 +1,70 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"api/internal/config"
+	"api/internal/modules/content/models"
+)
+
+func TestBuildPostSEO(t *testing.T) {
+	baseURL := strings.TrimRight(config.GetBaseURL(), "/")
+	post := &models.Post{
+		ID:         42,
+		Title:      "标题",
+		Slug:       "hello-world",
+		Excerpt:    "摘要",
+		Status:     "published",
+		Visibility: VisibilityPublic,
+		Author:     &models.AuthorSummary{ID: 1, Username: "alice", DisplayName: "Alice"},
+	}
+
+	seo := BuildPostSEO(post, nil)
+	if want := baseURL + "/posts/hello-world"; seo.CanonicalURL != want || seo.OpenGraph.URL != want {
+		t.Fatalf("canonical = %q, og:url = %q, want %q", seo.CanonicalURL, seo.OpenGraph.URL, want)
+	}
+	if seo.Description != "摘要" {
+		t.Fatalf("description = %q", seo.Description)
+	}
+	author, _ := seo.JSONLD["author"].(map[string]interface{})
+	if author["name"] != "Alice" {
+		t.Fatalf("json_ld author = %v", seo.JSONLD["author"])
+	}
+
+	post.CanonicalURL = "/archives/42"
+	if got := BuildPostSEO(post, nil).CanonicalURL; got != baseURL+"/archives/42" {
+		t.Fatalf("configured canonical = %q", got)
+	}
+}
+
+func TestBuildLockedPostSEO(t *testing.T) {
+	post := &models.Post{
+		ID:         7,
+		Title:      "加密文章",
+		Slug:       "secret",
+		Excerpt:    "不应出现的摘要",
+		Content:    "不应出现的正文内容",
+		Status:     "published",
+		Visibility: VisibilityPassword,
+		SEOMeta:    models.SEOMeta{MetaDescription: "不应出现的描述"},
+	}
+
+	seo := BuildLockedPostSEO(post)
+	body, err := json.Marshal(seo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"不应出现的摘要", "不应出现的正文", "不应出现的描述"} {
+		if strings.Contains(string(body), leaked) {
+			t.Fatalf("locked seo leaks %q: %s", leaked, body)
+		}
+	}
+	if seo.Title != "加密文章" || seo.Robots != "noindex, nofollow" {
+		t.Fatalf("title = %q, robots = %q", seo.Title, seo.Robots)
+	}
+	if post.Excerpt == "" || post.Content == "" {
+		t.Fatal("BuildLockedPostSEO must not modify the post")
+	}
+}
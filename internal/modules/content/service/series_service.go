@@ -0,0 +1,127 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var ErrSeriesSlugExists = errors.New("系列slug已存在")
+
+func CreateSeries(series *models.Series) error {
+	if series.Slug == "" {
+		series.Slug = GenerateSlug(series.Title)
+	}
+	if dao.SeriesSlugExists(series.Slug, 0) {
+		return ErrSeriesSlugExists
+	}
+	return dao.CreateSeries(series)
+}
+
+func GetSeriesByID(id uint64) (*models.Series, error) {
+	return dao.GetSeriesByID(id)
+}
+
+func GetSeriesBySlug(slug string) (*models.Series, error) {
+	return dao.GetSeriesBySlug(slug)
+}
+
+func ListSeries() ([]models.Series, error) {
+	return dao.ListSeries()
+}
+
+func UpdateSeries(series *models.Series) error {
+	if dao.SeriesSlugExists(series.Slug, series.ID) {
+		return ErrSeriesSlugExists
+	}
+	return dao.UpdateSeries(series)
+}
+
+func DeleteSeries(id uint64) error {
+	return dao.DeleteSeries(id)
+}
+
+// SetSeriesPosts 按 postIDs 的顺序重设系列文章（重复ID只保留第一次出现的位置）
+func SetSeriesPosts(seriesID uint64, postIDs []uint64) error {
+	seen := make(map[uint64]bool, len(postIDs))
+	ordered := make([]uint64, 0, len(postIDs))
+	for _, id := range postIDs {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, err := dao.GetPostByID(id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("文章 %d 不存在", id)
+			}
+			return err
+		}
+		ordered = append(ordered, id)
+	}
+	return dao.ReplaceSeriesPosts(seriesID, ordered)
+}
+
+// ListSeriesParts 查询系列文章；publicOnly 时只返回访客可见的文章，且密码文章不返回摘要
+func ListSeriesParts(seriesID uint64, publicOnly bool) ([]models.SeriesPart, error) {
+	parts, err := dao.ListSeriesParts(seriesID, publicOnly, 0)
+	if err != nil {
+		return nil, err
+	}
+	if publicOnly {
+		for i := range parts {
+			if parts[i].Visibility == VisibilityPassword {
+				parts[i].Excerpt = ""
+			}
+		}
+	}
+	return parts, nil
+}
+
+// GetPostSeriesNav 生成文章的系列导航（上一篇/下一篇只在访客可见的文章间跳转），不属于系列时返回 nil
+func GetPostSeriesNav(postID uint64) (*models.SeriesNav, error) {
+	rel, err := dao.GetSeriesPostByPostID(postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	series, err := dao.GetSeriesByID(rel.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := dao.ListSeriesParts(series.ID, true, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	nav := &models.SeriesNav{ID: series.ID, Title: series.Title, Slug: series.Slug, Total: len(parts)}
+	for i := range parts {
+		if parts[i].PostID != postID {
+			continue
+		}
+		nav.Position = i + 1
+		if i > 0 {
+			nav.Prev = seriesNavPart(parts[i-1])
+		}
+		if i+1 < len(parts) {
+			nav.Next = seriesNavPart(parts[i+1])
+		}
+		break
+	}
+	return nav, nil
+}
+
+// 导航中只保留标题与链接信息
+func seriesNavPart(part models.SeriesPart) *models.SeriesPart {
+	return &models.SeriesPart{
+		PostID:      part.PostID,
+		Position:    part.Position,
+		Title:       part.Title,
+		Slug:        part.Slug,
+		PublishedAt: part.PublishedAt,
+	}
+}
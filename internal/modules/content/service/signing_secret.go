@@ -0,0 +1,34 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+var (
+	signingSecretsMu sync.Mutex
+	signingSecrets   = make(map[string][]byte)
+)
+
+// signingSecret 返回签名密钥；未配置时生成进程内随机密钥（重启或多实例间签名不通用）
+func signingSecret(envName, configured string) []byte {
+	signingSecretsMu.Lock()
+	defer signingSecretsMu.Unlock()
+
+	if secret, ok := signingSecrets[envName]; ok {
+		return secret
+	}
+	secret := []byte(configured)
+	if configured == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			panic(fmt.Sprintf("generate %s: %v", envName, err))
+		}
+		secret = []byte(hex.EncodeToString(buf))
+		fmt.Printf("[signing] %s 未配置，使用随机密钥，重启后已签发的链接/令牌将失效\n", envName)
+	}
+	signingSecrets[envName] = secret
+	return secret
+}
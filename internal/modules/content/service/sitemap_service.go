@@ -0,0 +1,320 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// 单个 sitemap 文件的 URL 上限（sitemaps.org 协议规定）
+	maxSitemapURLs = 50000
+
+	sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	xhtmlNS   = "http://www.w3.org/1999/xhtml"
+)
+
+var ErrSitemapNotFound = errors.New("sitemap 不存在")
+
+// SitemapFile 渲染好的 sitemap 文件
+type SitemapFile struct {
+	Body         []byte
+	LastModified time.Time
+}
+
+type sitemapSet struct {
+	generation uint64
+	expiresAt  time.Time
+	root       *SitemapFile   // /sitemap.xml：URL 数量未超限时为 urlset，否则为 sitemapindex
+	parts      []*SitemapFile // 拆分后的分片 /sitemap/<n>.xml，未拆分时为空
+}
+
+var (
+	sitemapGeneration    uint64
+	sitemapRebuildQueued atomic.Bool
+	sitemapBuildMu       sync.Mutex // 串行化重建，避免并发请求重复查询
+	sitemapMu            sync.RWMutex
+	sitemapCache         *sitemapSet
+)
+
+// GetSitemap 获取 sitemap 文件：part 为 0 时返回 /sitemap.xml，否则返回第 part 个分片
+func GetSitemap(part int) (*SitemapFile, error) {
+	set, err := loadSitemap()
+	if err != nil {
+		return nil, err
+	}
+	if part == 0 {
+		return set.root, nil
+	}
+	if part < 0 || part > len(set.parts) {
+		return nil, ErrSitemapNotFound
+	}
+	return set.parts[part-1], nil
+}
+
+// InvalidateSitemap 内容发布、删除或调整后调用，在后台重新生成 sitemap。
+// 批量变更时只保留一个排队中的重建任务，它会读取最新的版本号。
+func InvalidateSitemap() {
+	atomic.AddUint64(&sitemapGeneration, 1)
+	if !sitemapRebuildQueued.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		sitemapBuildMu.Lock()
+		defer sitemapBuildMu.Unlock()
+		sitemapRebuildQueued.Store(false)
+		if _, err := buildSitemapLocked(atomic.LoadUint64(&sitemapGeneration)); err != nil {
+			fmt.Printf("[sitemap] rebuild error: %v\n", err)
+		}
+	}()
+}
+
+func loadSitemap() (*sitemapSet, error) {
+	generation := atomic.LoadUint64(&sitemapGeneration)
+	if cached := cachedSitemap(generation); cached != nil {
+		return cached, nil
+	}
+	sitemapBuildMu.Lock()
+	defer sitemapBuildMu.Unlock()
+	return buildSitemapLocked(generation)
+}
+
+// cachedSitemap 返回未过期且不旧于 generation 的缓存
+func cachedSitemap(generation uint64) *sitemapSet {
+	sitemapMu.RLock()
+	defer sitemapMu.RUnlock()
+	if sitemapCache != nil && sitemapCache.generation >= generation && time.Now().Before(sitemapCache.expiresAt) {
+		return sitemapCache
+	}
+	return nil
+}
+
+// buildSitemapLocked 需持有 sitemapBuildMu
+func buildSitemapLocked(generation uint64) (*sitemapSet, error) {
+	// 等待期间其他调用可能已经生成了同代或更新的结果
+	if cached := cachedSitemap(generation); cached != nil {
+		return cached, nil
+	}
+
+	urls, err := collectSitemapURLs()
+	if err != nil {
+		return nil, err
+	}
+	set, err := renderSitemapSet(urls)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := config.Load().SitemapCacheTTL
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	set.generation = generation
+	set.expiresAt = time.Now().Add(ttl)
+
+	sitemapMu.Lock()
+	if sitemapCache == nil || sitemapCache.generation <= generation {
+		sitemapCache = set
+	}
+	sitemapMu.Unlock()
+	return set, nil
+}
+
+type sitemapURL struct {
+	Loc        string             `xml:"loc"`
+	LastMod    string             `xml:"lastmod,omitempty"`
+	Alternates []sitemapAlternate `xml:"xhtml:link"`
+	updated    time.Time
+}
+
+// sitemapAlternate 其他语言版本：<xhtml:link rel="alternate" hreflang="en" href="..."/>
+type sitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	HrefLang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	NS      string       `xml:"xmlns,attr"`
+	XHTMLNS string       `xml:"xmlns:xhtml,attr,omitempty"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapIndexEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name            `xml:"sitemapindex"`
+	NS       string              `xml:"xmlns,attr"`
+	Sitemaps []sitemapIndexEntry `xml:"sitemap"`
+}
+
+// collectSitemapURLs 汇总首页、页面、分类、标签与文章的前台地址
+func collectSitemapURLs() ([]sitemapURL, error) {
+	baseURL := strings.TrimRight(config.GetBaseURL(), "/")
+
+	pages, err := dao.ListSitemapPages()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := dao.ListSitemapCategories()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := dao.ListSitemapTags()
+	if err != nil {
+		return nil, err
+	}
+	posts, err := dao.ListSitemapPosts()
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]sitemapURL, 0, 1+len(pages)+len(categories)+len(tags)+len(posts))
+	urls = append(urls, sitemapURL{Loc: baseURL + "/"})
+	appendEntries := func(prefix string, entries []dao.SitemapEntry) {
+		alternates := sitemapAlternates(baseURL+prefix, entries)
+		for _, entry := range entries {
+			item := newSitemapURL(baseURL+prefix+url.PathEscape(entry.Key), entry.UpdatedAt)
+			item.Alternates = alternates[entry.TranslationGroup]
+			urls = append(urls, item)
+			if entry.UpdatedAt.After(urls[0].updated) {
+				urls[0] = newSitemapURL(urls[0].Loc, entry.UpdatedAt)
+			}
+		}
+	}
+	appendEntries("/pages/", pages)
+	appendEntries("/categories/", categories)
+	appendEntries("/tags/", tags)
+	appendEntries("/posts/", posts)
+	return urls, nil
+}
+
+// sitemapAlternates 按翻译组汇总收录在 sitemap 中的各语言版本；组内只有一个版本时不输出。
+// 同一组的每个地址都列出包括自身在内的全部版本
+func sitemapAlternates(prefix string, entries []dao.SitemapEntry) map[string][]sitemapAlternate {
+	groups := make(map[string][]sitemapAlternate)
+	for _, entry := range entries {
+		if entry.TranslationGroup == "" || entry.Lang == "" {
+			continue
+		}
+		groups[entry.TranslationGroup] = append(groups[entry.TranslationGroup], sitemapAlternate{
+			Rel:      "alternate",
+			HrefLang: entry.Lang,
+			Href:     prefix + url.PathEscape(entry.Key),
+		})
+	}
+	for group, alternates := range groups {
+		if len(alternates) < 2 {
+			delete(groups, group)
+			continue
+		}
+		sort.SliceStable(alternates, func(i, j int) bool { return alternates[i].HrefLang < alternates[j].HrefLang })
+	}
+	return groups
+}
+
+func newSitemapURL(loc string, updated time.Time) sitemapURL {
+	item := sitemapURL{Loc: loc, updated: updated}
+	if !updated.IsZero() {
+		item.LastMod = updated.UTC().Format(time.RFC3339)
+	}
+	return item
+}
+
+// renderSitemapSet 不超过上限时直接输出 urlset，否则拆分为多个分片并生成 sitemapindex
+func renderSitemapSet(urls []sitemapURL) (*sitemapSet, error) {
+	if len(urls) <= maxSitemapURLs {
+		root, err := renderSitemapURLSet(urls)
+		if err != nil {
+			return nil, err
+		}
+		return &sitemapSet{root: root}, nil
+	}
+
+	baseURL := strings.TrimRight(config.GetBaseURL(), "/")
+	set := &sitemapSet{}
+	index := sitemapIndex{NS: sitemapNS}
+	var latest time.Time
+	for start := 0; start < len(urls); start += maxSitemapURLs {
+		end := start + maxSitemapURLs
+		if end > len(urls) {
+			end = len(urls)
+		}
+		part, err := renderSitemapURLSet(urls[start:end])
+		if err != nil {
+			return nil, err
+		}
+		set.parts = append(set.parts, part)
+
+		entry := sitemapIndexEntry{Loc: baseURL + "/sitemap/" + strconv.Itoa(len(set.parts)) + ".xml"}
+		if !part.LastModified.IsZero() {
+			entry.LastMod = part.LastModified.Format(time.RFC3339)
+		}
+		index.Sitemaps = append(index.Sitemaps, entry)
+		if part.LastModified.After(latest) {
+			latest = part.LastModified
+		}
+	}
+
+	body, err := marshalXMLDocument(index)
+	if err != nil {
+		return nil, err
+	}
+	set.root = &SitemapFile{Body: body, LastModified: latest}
+	return set, nil
+}
+
+func renderSitemapURLSet(urls []sitemapURL) (*SitemapFile, error) {
+	var latest time.Time
+	doc := sitemapURLSet{NS: sitemapNS, URLs: urls}
+	for _, item := range urls {
+		if item.updated.After(latest) {
+			latest = item.updated
+		}
+		if len(item.Alternates) > 0 {
+			doc.XHTMLNS = xhtmlNS
+		}
+	}
+	body, err := marshalXMLDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &SitemapFile{Body: body, LastModified: latest.UTC().Truncate(time.Second)}, nil
+}
+
+// RobotsTxt 返回 robots.txt 内容：配置了 ROBOTS_TXT_FILE 时原样输出该文件，
+// 否则按 ROBOTS_DISALLOW 生成，并附带 sitemap 地址
+func RobotsTxt() string {
+	cfg := config.Load()
+	if cfg.RobotsTxtFile != "" {
+		content, err := os.ReadFile(cfg.RobotsTxtFile)
+		if err == nil {
+			return string(content)
+		}
+		fmt.Printf("[sitemap] read robots.txt file %s error: %v\n", cfg.RobotsTxtFile, err)
+	}
+
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	for _, path := range strings.Split(cfg.RobotsDisallow, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			b.WriteString("Disallow: " + path + "\n")
+		}
+	}
+	b.WriteString("\nSitemap: " + strings.TrimRight(config.GetBaseURL(), "/") + "/sitemap.xml\n")
+	return b.String()
+}
@@ -6,17 +6,32 @@ import (
 )
 
 func CreateTag(tag *models.Tag) error {
-	return dao.CreateTag(tag)
+	if err := dao.CreateTag(tag); err != nil {
+		return err
+	}
+	notifyPostsChanged()
+	return nil
 }
 func GetTagByID(id uint64) (*models.Tag, error) {
 	return dao.GetTagByID(id)
 }
+func GetTagBySlug(slug string) (*models.Tag, error) {
+	return dao.GetTagBySlug(slug)
+}
 func ListTags() ([]models.Tag, error) {
 	return dao.ListTags()
 }
 func UpdateTag(tag *models.Tag) error {
-	return dao.UpdateTag(tag)
+	if err := dao.UpdateTag(tag); err != nil {
+		return err
+	}
+	notifyPostsChanged()
+	return nil
 }
 func DeleteTag(id uint64) error {
-	return dao.DeleteTag(id)
+	if err := dao.DeleteTag(id); err != nil {
+		return err
+	}
+	notifyPostsChanged()
+	return nil
 }
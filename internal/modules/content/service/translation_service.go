@@ -0,0 +1,249 @@
+package service
+
+import (
+	"api/internal/config"
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const fallbackLang = "zh-CN"
+
+var (
+	ErrInvalidLang       = errors.New("无效的语言代码，示例：zh-CN、en、zh-Hant-TW")
+	ErrTranslationSource = errors.New("要关联的原文不存在")
+	ErrTranslationExists = errors.New("翻译组中已有该语言的版本")
+)
+
+var langPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// NormalizeLang 校验 BCP 47 语言代码并统一大小写：语言小写、文字首字母大写、地区大写，
+// 如 ZH_hant_tw -> zh-Hant-TW
+func NormalizeLang(raw string) (string, error) {
+	raw = strings.ReplaceAll(strings.TrimSpace(raw), "_", "-")
+	if len(raw) > 16 || !langPattern.MatchString(raw) {
+		return "", ErrInvalidLang
+	}
+	parts := strings.Split(raw, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch {
+		case len(parts[i]) == 4 && i == 1:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		case len(parts[i]) == 2 || (len(parts[i]) == 3 && isDigits(parts[i])):
+			parts[i] = strings.ToUpper(parts[i])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseLangFilter 解析列表、订阅源的 lang 筛选参数，为空表示不按语言筛选
+func ParseLangFilter(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", nil
+	}
+	return NormalizeLang(raw)
+}
+
+// DefaultLang 站点默认语言（SITE_LANG），配置无效时使用 zh-CN
+func DefaultLang() string {
+	if lang, err := NormalizeLang(config.Load().SiteLang); err == nil {
+		return lang
+	}
+	return fallbackLang
+}
+
+// TranslationInput 后台提交的语言与翻译组修改：Lang 为 nil 时不修改语言；
+// TranslationOf 为 nil 时不调整翻译组，为 0 时脱离原翻译组，否则加入该内容所在的翻译组
+type TranslationInput struct {
+	Lang          *string
+	TranslationOf *uint64
+}
+
+// ApplyPostTranslation 设置文章语言与翻译组，原文尚未加入翻译组时为其新建一个；
+// 返回的写入（可能为 nil）需传给 CreatePost/UpdatePost，与文章保存在同一事务中写入原文的翻译组
+func ApplyPostTranslation(post *models.Post, in TranslationInput) (ContentWrite, error) {
+	return applyTranslation(&models.Post{}, post.ID, &post.TranslationMeta, in, func(id uint64) (*models.TranslationMeta, error) {
+		source, err := dao.GetPostByID(id)
+		if err != nil || source.Status == StatusTrash {
+			return nil, err
+		}
+		return &source.TranslationMeta, nil
+	})
+}
+
+// ApplyPageTranslation 设置页面语言与翻译组，返回值同 ApplyPostTranslation
+func ApplyPageTranslation(page *models.Page, in TranslationInput) (ContentWrite, error) {
+	return applyTranslation(&models.Page{}, page.ID, &page.TranslationMeta, in, func(id uint64) (*models.TranslationMeta, error) {
+		source, err := dao.GetPageByID(id)
+		if err != nil {
+			return nil, err
+		}
+		return &source.TranslationMeta, nil
+	})
+}
+
+// applyTranslation loadSource 返回 nil 表示原文不可关联（如已在回收站）
+func applyTranslation(model interface{}, id uint64, meta *models.TranslationMeta, in TranslationInput,
+	loadSource func(id uint64) (*models.TranslationMeta, error),
+) (ContentWrite, error) {
+	if in.Lang != nil && strings.TrimSpace(*in.Lang) != "" {
+		lang, err := NormalizeLang(*in.Lang)
+		if err != nil {
+			return nil, err
+		}
+		meta.Lang = lang
+	}
+	if meta.Lang == "" {
+		meta.Lang = DefaultLang()
+	}
+
+	var sourceID uint64
+	var sourceMeta *models.TranslationMeta
+	if in.TranslationOf != nil {
+		sourceID = *in.TranslationOf
+		switch {
+		case sourceID == 0:
+			meta.TranslationGroup = ""
+		case sourceID == id:
+			return nil, ErrTranslationSource
+		default:
+			source, err := loadSource(sourceID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			if source == nil || err != nil {
+				return nil, ErrTranslationSource
+			}
+			if source.Lang == meta.Lang {
+				return nil, ErrTranslationExists
+			}
+			sourceMeta = source
+			meta.TranslationGroup = source.TranslationGroup
+			if meta.TranslationGroup == "" {
+				meta.TranslationGroup = uuid.NewString()
+			}
+		}
+	}
+
+	if meta.TranslationGroup != "" && (in.Lang != nil || in.TranslationOf != nil) {
+		taken, err := dao.TranslationLangTaken(model, meta.TranslationGroup, meta.Lang, id)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, ErrTranslationExists
+		}
+	}
+	// 原文第一次被关联时，随本次保存写入新建的翻译组
+	if sourceMeta != nil && sourceMeta.TranslationGroup == "" {
+		return claimTranslationGroup(model, id, sourceID, meta), nil
+	}
+	return nil, nil
+}
+
+// claimTranslationGroup 只在原文仍未加入翻译组时写入新建的组；并发关联同一原文时
+// 后提交的请求改为加入先写入的组，并重新检查该组中是否已有相同语言
+func claimTranslationGroup(model interface{}, id, sourceID uint64, meta *models.TranslationMeta) ContentWrite {
+	return func(t *dao.ContentTx) error {
+		group, err := t.ClaimTranslationGroup(model, sourceID, meta.TranslationGroup)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTranslationSource
+		}
+		if err != nil {
+			return err
+		}
+		if group == meta.TranslationGroup {
+			return nil
+		}
+		meta.TranslationGroup = group
+		taken, err := t.TranslationLangTaken(model, group, meta.Lang, id)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrTranslationExists
+		}
+		return nil
+	}
+}
+
+// AttachPostTranslations 填充文章的其他语言版本；publicOnly 时只列出访客可见的版本
+func AttachPostTranslations(post *models.Post, publicOnly bool) {
+	if post.TranslationGroup == "" {
+		return
+	}
+	links, err := dao.ListPostTranslations(post.TranslationGroup, publicOnly)
+	if err != nil {
+		return
+	}
+	post.Translations = excludeTranslation(links, post.ID)
+}
+
+// AttachPageTranslations 填充页面的其他语言版本；publishedOnly 时只列出已发布的版本
+func AttachPageTranslations(page *models.Page, publishedOnly bool) {
+	if page.TranslationGroup == "" {
+		return
+	}
+	links, err := dao.ListPageTranslations(page.TranslationGroup, publishedOnly)
+	if err != nil {
+		return
+	}
+	page.Translations = excludeTranslation(links, page.ID)
+}
+
+func excludeTranslation(links []models.TranslationLink, id uint64) []models.TranslationLink {
+	result := make([]models.TranslationLink, 0, len(links))
+	for _, link := range links {
+		if link.ID != id {
+			result = append(result, link)
+		}
+	}
+	return result
+}
+
+// HrefLangAlternate 某一语言版本的地址，对应 <link rel="alternate" hreflang="..." href="...">
+type HrefLangAlternate struct {
+	Lang string `json:"hreflang"`
+	URL  string `json:"href"`
+}
+
+// hrefLangAlternates 生成包含自身在内的全部语言版本，没有其他语言版本时返回 nil
+func hrefLangAlternates(selfLang, selfURL string, links []models.TranslationLink, urlOf func(models.TranslationLink) string) []HrefLangAlternate {
+	if len(links) == 0 {
+		return nil
+	}
+	alternates := []HrefLangAlternate{{Lang: selfLang, URL: selfURL}}
+	for _, link := range links {
+		alternates = append(alternates, HrefLangAlternate{Lang: link.Lang, URL: urlOf(link)})
+	}
+	sort.SliceStable(alternates, func(i, j int) bool { return alternates[i].Lang < alternates[j].Lang })
+	return alternates
+}
+
+// postURL 文章前台地址以当前 slug 为准，历史 slug 由详情接口跳转
+func postURL(baseURL, slug string) string {
+	return baseURL + "/posts/" + url.PathEscape(slug)
+}
+
+func pageURL(baseURL, slug string) string {
+	return baseURL + "/pages/" + url.PathEscape(slug)
+}
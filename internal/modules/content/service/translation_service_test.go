@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"api/internal/modules/content/models"
+)
+
+func TestNormalizeLang(t *testing.T) {
+	cases := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"zh-CN", "zh-CN", false},
+		{"EN", "en", false},
+		{" en-us ", "en-US", false},
+		{"ZH_hant_tw", "zh-Hant-TW", false},
+		{"es-419", "es-419", false},
+		{"sr-latn-rs", "sr-Latn-RS", false},
+		{"", "", true},
+		{"english", "", true},
+		{"zh--CN", "", true},
+		{"zh CN", "", true},
+		{"zh-Hant-TW-abcdefgh", "", true},
+	}
+	for _, tc := range cases {
+		got, err := NormalizeLang(tc.raw)
+		if tc.wantErr {
+			if !errors.Is(err, ErrInvalidLang) {
+				t.Errorf("NormalizeLang(%q) = %q, %v, want ErrInvalidLang", tc.raw, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("NormalizeLang(%q) = %q, %v, want %q", tc.raw, got, err, tc.want)
+		}
+	}
+
+	if lang, err := ParseLangFilter("  "); lang != "" || err != nil {
+		t.Errorf("ParseLangFilter(blank) = %q, %v, want no filter", lang, err)
+	}
+}
+
+func TestHrefLangAlternates(t *testing.T) {
+	urlOf := func(link models.TranslationLink) string { return pageURL("https://example.com", link.Slug) }
+	links := []models.TranslationLink{
+		{ID: 1, Lang: "zh-CN", Slug: "guanyu"},
+		{ID: 2, Lang: "en", Slug: "about"},
+		{ID: 3, Lang: "ja", Slug: "about-ja"},
+	}
+
+	// 翻译列表中包含自身时先排除，再由 hrefLangAlternates 补上自身地址
+	others := excludeTranslation(links, 1)
+	got := hrefLangAlternates("zh-CN", "https://example.com/pages/guanyu", others, urlOf)
+	want := []HrefLangAlternate{
+		{Lang: "en", URL: "https://example.com/pages/about"},
+		{Lang: "ja", URL: "https://example.com/pages/about-ja"},
+		{Lang: "zh-CN", URL: "https://example.com/pages/guanyu"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("hrefLangAlternates() = %+v, want %+v", got, want)
+	}
+
+	if got := hrefLangAlternates("zh-CN", "https://example.com/pages/guanyu", excludeTranslation(links[:1], 1), urlOf); got != nil {
+		t.Fatalf("no translations = %+v, want nil", got)
+	}
+}
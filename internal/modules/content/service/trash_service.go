@@ -0,0 +1,151 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	StatusTrash = "trash"
+
+	TrashTypePost    = "post"
+	TrashTypeComment = "comment"
+
+	defaultTrashPurgeInterval = time.Hour
+	trashPurgeBatchSize       = 100
+	trashPurgerLockKey        = "content:trash_purger:lock"
+)
+
+// ErrInvalidTrashType 回收站只收纳文章和评论
+var ErrInvalidTrashType = errors.New("无效的回收站类型，可选值：post、comment")
+
+var trashPurgerOnce sync.Once
+
+// TrashPurgeReport 彻底删除的数量
+type TrashPurgeReport struct {
+	Posts    int `json:"posts"`
+	Comments int `json:"comments"`
+}
+
+// TrashPosts 将文章移入回收站，返回实际移入的数量
+func TrashPosts(ids []uint64) (int64, error) {
+	moved, err := dao.TrashPosts(ids, time.Now())
+	if moved > 0 {
+		recountPostTaxonomies(ids, nil, nil)
+		notifyPostsChanged(ids...)
+	}
+	return moved, err
+}
+
+// TrashComments 将评论移入回收站，返回实际移入的数量
+func TrashComments(ids []uint64) (int64, error) {
+	return dao.TrashComments(ids, time.Now())
+}
+
+// RestoreTrash 将回收站中的文章或评论恢复为移入前的状态，返回实际恢复的数量
+func RestoreTrash(itemType string, ids []uint64) (int64, error) {
+	switch itemType {
+	case TrashTypePost:
+		restored, err := dao.RestorePosts(ids)
+		if restored > 0 {
+			recountPostTaxonomies(ids, nil, nil)
+			notifyPostsChanged(ids...)
+		}
+		return restored, err
+	case TrashTypeComment:
+		return dao.RestoreComments(ids)
+	default:
+		return 0, ErrInvalidTrashType
+	}
+}
+
+// PurgeTrash 彻底删除移入回收站早于 before 的内容；itemType 为空时处理文章和评论，
+// before 为零值时清空回收站
+func PurgeTrash(itemType string, before time.Time) (*TrashPurgeReport, error) {
+	report := &TrashPurgeReport{}
+	if itemType != "" && itemType != TrashTypePost && itemType != TrashTypeComment {
+		return report, ErrInvalidTrashType
+	}
+
+	var err error
+	if itemType != TrashTypeComment {
+		report.Posts, err = purgeTrashed(before, dao.ListTrashedPostIDs, dao.PurgePost)
+		if err != nil {
+			return report, err
+		}
+	}
+	if itemType != TrashTypePost {
+		report.Comments, err = purgeTrashed(before, dao.ListTrashedCommentIDs, dao.PurgeComment)
+	}
+	return report, err
+}
+
+// PurgeTrashItem 彻底删除回收站中的单条内容，不在回收站中时返回 false
+func PurgeTrashItem(itemType string, id uint64) (bool, error) {
+	switch itemType {
+	case TrashTypePost:
+		return dao.PurgePost(id)
+	case TrashTypeComment:
+		return dao.PurgeComment(id)
+	default:
+		return false, ErrInvalidTrashType
+	}
+}
+
+func purgeTrashed(before time.Time, list func(time.Time, int) ([]uint64, error), purge func(uint64) (bool, error)) (int, error) {
+	purged := 0
+	for {
+		ids, err := list(before, trashPurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		for _, id := range ids {
+			ok, err := purge(id)
+			if err != nil {
+				return purged, err
+			}
+			if ok {
+				purged++
+			}
+		}
+		if len(ids) < trashPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// StartTrashPurger 启动回收站清理任务，周期性地彻底删除移入回收站超过 retentionDays 天的文章与评论
+func StartTrashPurger(interval time.Duration, retentionDays int) {
+	if retentionDays <= 0 {
+		return
+	}
+	trashPurgerOnce.Do(func() {
+		if interval <= 0 {
+			interval = defaultTrashPurgeInterval
+		}
+		retention := time.Duration(retentionDays) * 24 * time.Hour
+		go runTrashPurger(interval, retention)
+	})
+}
+
+func runTrashPurger(interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := runWithInstanceLock(trashPurgerLockKey, interval, func() error {
+			report, err := PurgeTrash("", time.Now().Add(-retention))
+			if report.Posts > 0 || report.Comments > 0 {
+				fmt.Printf("[trash] purged %d posts, %d comments\n", report.Posts, report.Comments)
+			}
+			return err
+		})
+		if err != nil {
+			fmt.Printf("[trash] purge error: %v\n", err)
+		}
+		<-ticker.C
+	}
+}
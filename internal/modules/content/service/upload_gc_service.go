@@ -0,0 +1,286 @@
+package service
+
+import (
+	"api/internal/modules/media"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultUploadQuarantineDir 隔离目录放在上传目录之外，避免隔离的文件仍能通过 /uploads 访问
+	DefaultUploadQuarantineDir = "./uploads_quarantine"
+	quarantineBatchLayout      = "20060102-150405"
+)
+
+var (
+	ErrEmptyUploadIndex       = errors.New("没有找到任何上传文件引用，可能连接了错误的数据库；确认无误后使用强制模式")
+	ErrInvalidQuarantineBatch = errors.New("无效的隔离批次")
+)
+
+// UploadGCOptions 孤立文件清理选项；Apply 为 false 时只统计将要处理的文件
+type UploadGCOptions struct {
+	QuarantineDir string
+	MinAge        time.Duration // 隔离：只处理最后修改时间早于该时长的文件
+	Keep          time.Duration // 清除：隔离超过该时长的批次才会被彻底删除
+	Batch         string        // 恢复：指定批次，为空时恢复全部批次
+	Apply         bool
+	Force         bool // 引用索引为空时仍继续执行
+}
+
+// UploadGCReport 清理结果；Files 为已处理（或试运行时将处理）的文件
+type UploadGCReport struct {
+	Batches  []string     `json:"batches"`
+	Files    []UploadFile `json:"files"`
+	Bytes    int64        `json:"bytes"`
+	Restored []string     `json:"restored,omitempty"` // 清除前发现重新被引用、已移回上传目录的文件
+	Failed   []string     `json:"failed,omitempty"`
+	Applied  bool         `json:"applied"`
+}
+
+func (r *UploadGCReport) add(file UploadFile) {
+	r.Files = append(r.Files, file)
+	r.Bytes += file.Size
+}
+
+func (r *UploadGCReport) fail(sitePath string, err error) {
+	r.Failed = append(r.Failed, fmt.Sprintf("%s: %v", sitePath, err))
+}
+
+func quarantineDir(opts UploadGCOptions) string {
+	if dir := strings.TrimSpace(opts.QuarantineDir); dir != "" {
+		return dir
+	}
+	return DefaultUploadQuarantineDir
+}
+
+// checkUploadIndex 索引为空而又有文件待处理时，通常意味着连接了错误的数据库，
+// 此时所有文件都会被当作孤立文件，除非显式强制否则拒绝继续
+func checkUploadIndex(index UploadIndex, pending int, force bool) error {
+	if len(index) == 0 && pending > 0 && !force {
+		return ErrEmptyUploadIndex
+	}
+	return nil
+}
+
+// QuarantineOrphanUploads 把孤立文件按原相对路径移动到隔离目录下以当前时间命名的批次中，
+// 确认无误后再由 PurgeUploadQuarantine 彻底删除
+func QuarantineOrphanUploads(opts UploadGCOptions) (*UploadGCReport, error) {
+	index, err := BuildUploadIndex()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	orphans, err := orphanUploads(index, opts.MinAge, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUploadIndex(index, len(orphans), opts.Force); err != nil {
+		return nil, err
+	}
+
+	batch := now.Format(quarantineBatchLayout)
+	batchDir := filepath.Join(quarantineDir(opts), batch)
+	report := &UploadGCReport{Batches: []string{batch}, Files: []UploadFile{}, Applied: opts.Apply}
+	for _, file := range orphans {
+		if opts.Apply {
+			rel := filepath.FromSlash(strings.TrimPrefix(file.Path, media.PublicURL+"/"))
+			if err := moveFile(filepath.Join(media.UploadDir, rel), filepath.Join(batchDir, rel)); err != nil {
+				report.fail(file.Path, err)
+				continue
+			}
+		}
+		report.add(file)
+	}
+	return report, nil
+}
+
+// PurgeUploadQuarantine 彻底删除隔离超过 Keep 的批次；删除前重新检查引用，
+// 隔离期间又被内容引用的文件移回上传目录
+func PurgeUploadQuarantine(opts UploadGCOptions) (*UploadGCReport, error) {
+	batches, err := listQuarantineBatches(quarantineDir(opts))
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().Add(-opts.Keep)
+	expired := make([]string, 0, len(batches))
+	for _, batch := range batches {
+		if createdAt, _ := time.ParseInLocation(quarantineBatchLayout, batch, time.Local); !createdAt.After(cutoff) {
+			expired = append(expired, batch)
+		}
+	}
+	index, err := BuildUploadIndex()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUploadIndex(index, len(expired), opts.Force); err != nil {
+		return nil, err
+	}
+
+	report := &UploadGCReport{Batches: expired, Files: []UploadFile{}, Applied: opts.Apply}
+	for _, batch := range expired {
+		batchDir := filepath.Join(quarantineDir(opts), batch)
+		err := walkQuarantineBatch(batchDir, func(file UploadFile, src string) {
+			if len(index[file.Path]) > 0 {
+				if opts.Apply {
+					if err := restoreQuarantinedFile(src, file.Path); err != nil {
+						report.fail(file.Path, err)
+						return
+					}
+				}
+				report.Restored = append(report.Restored, file.Path)
+				return
+			}
+			if opts.Apply {
+				if err := os.Remove(src); err != nil {
+					report.fail(file.Path, err)
+					return
+				}
+			}
+			report.add(file)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if opts.Apply {
+			removeEmptyDirs(batchDir)
+		}
+	}
+	return report, nil
+}
+
+// RestoreUploadQuarantine 把隔离的文件移回上传目录，原位置已有同名文件时跳过
+func RestoreUploadQuarantine(opts UploadGCOptions) (*UploadGCReport, error) {
+	dir := quarantineDir(opts)
+	batches, err := listQuarantineBatches(dir)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Batch != "" {
+		found := false
+		for _, batch := range batches {
+			if batch == opts.Batch {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrInvalidQuarantineBatch
+		}
+		batches = []string{opts.Batch}
+	}
+
+	report := &UploadGCReport{Batches: batches, Files: []UploadFile{}, Applied: opts.Apply}
+	for _, batch := range batches {
+		batchDir := filepath.Join(dir, batch)
+		err := walkQuarantineBatch(batchDir, func(file UploadFile, src string) {
+			if opts.Apply {
+				if err := restoreQuarantinedFile(src, file.Path); err != nil {
+					report.fail(file.Path, err)
+					return
+				}
+			}
+			report.add(file)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if opts.Apply {
+			removeEmptyDirs(batchDir)
+		}
+	}
+	return report, nil
+}
+
+// listQuarantineBatches 按时间顺序列出隔离批次，忽略不符合命名格式的目录
+func listQuarantineBatches(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	batches := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(quarantineBatchLayout, entry.Name()); err == nil {
+			batches = append(batches, entry.Name())
+		}
+	}
+	sort.Strings(batches)
+	return batches, nil
+}
+
+// walkQuarantineBatch 遍历批次中的文件，file.Path 为文件原来的 /uploads/... 路径
+func walkQuarantineBatch(batchDir string, fn func(file UploadFile, src string)) error {
+	now := time.Now()
+	return filepath.Walk(batchDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(batchDir, p)
+		if err != nil {
+			return err
+		}
+		fn(UploadFile{
+			Path:       path.Join(media.PublicURL, filepath.ToSlash(rel)),
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime(),
+			AgeDays:    int(now.Sub(info.ModTime()).Hours() / 24),
+		}, p)
+		return nil
+	})
+}
+
+func restoreQuarantinedFile(src, sitePath string) error {
+	dst := filepath.Join(media.UploadDir, filepath.FromSlash(strings.TrimPrefix(sitePath, media.PublicURL+"/")))
+	if _, err := os.Stat(dst); err == nil {
+		return errors.New("上传目录中已存在同名文件")
+	}
+	return moveFile(src, dst)
+}
+
+// moveFile 移动文件并保留修改时间；跨文件系统无法直接重命名时改为复制后删除
+func moveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if err := copyFile(src, dst); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	_ = os.Chtimes(dst, info.ModTime(), info.ModTime())
+	return os.Remove(src)
+}
+
+// removeEmptyDirs 自底向上删除批次中的空目录（包括批次目录本身）
+func removeEmptyDirs(root string) {
+	var dirs []string
+	_ = filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err == nil && info.IsDir() {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+	for i := len(dirs) - 1; i >= 0; i-- {
+		_ = os.Remove(dirs[i]) // 非空目录删除失败，忽略即可
+	}
+}
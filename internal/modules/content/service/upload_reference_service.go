@@ -0,0 +1,229 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"api/internal/modules/media"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 引用上传文件的来源类型
+const (
+	UploadUsagePost     = "post"
+	UploadUsageRevision = "revision"
+	UploadUsageAutosave = "autosave"
+	UploadUsagePage     = "page"
+	UploadUsageMoment   = "moment"
+	UploadUsageSeries   = "series"
+	UploadUsageAvatar   = "avatar"
+)
+
+var ErrInvalidUploadPath = errors.New("无效的上传文件路径")
+
+// UploadUsage 上传文件的一处引用
+type UploadUsage struct {
+	Type  string `json:"type"`
+	ID    uint64 `json:"id"`
+	Title string `json:"title"`
+}
+
+// UploadIndex 上传文件引用索引：/uploads/... 路径 -> 引用它的内容
+type UploadIndex map[string][]UploadUsage
+
+// UploadFile 上传目录中的文件及其引用情况
+type UploadFile struct {
+	Path       string        `json:"path"` // /uploads/... 站内路径
+	URL        string        `json:"url"`
+	Size       int64         `json:"size"`
+	ModifiedAt time.Time     `json:"modified_at"`
+	AgeDays    int           `json:"age_days"`
+	References []UploadUsage `json:"references,omitempty"`
+}
+
+// BuildUploadIndex 扫描文章（含修订与自动保存）、页面、动态、系列封面和用户头像，
+// 建立上传文件到引用内容的索引。回收站中的文章仍可恢复，同样计入引用
+func BuildUploadIndex() (UploadIndex, error) {
+	index := make(UploadIndex)
+	extractor := newLinkExtractor()
+	// 同一文章的多条修订只记一次
+	seen := make(map[string]bool)
+	add := func(usage UploadUsage, content string, extras ...string) {
+		normalized := make([]string, 0, len(extras))
+		for _, extra := range extras {
+			normalized = append(normalized, normalizeUploadRef(extra))
+		}
+		for _, ref := range extractor.extract(usage.Type, usage.ID, usage.Title, content, normalized...) {
+			if ref.kind != LinkKindUpload {
+				continue
+			}
+			key := fmt.Sprintf("%s|%s|%d", ref.target, usage.Type, usage.ID)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			index[ref.target] = append(index[ref.target], usage)
+		}
+	}
+
+	posts, err := dao.ListPosts()
+	if err != nil {
+		return nil, err
+	}
+	for i := range posts {
+		post := &posts[i]
+		add(UploadUsage{Type: UploadUsagePost, ID: post.ID, Title: post.Title}, post.Content, post.CoverImage, post.OGImage)
+	}
+
+	// 修订与自动保存中的引用在恢复时会重新生效，不能当作孤立文件
+	err = dao.ListRevisionContents(func(revisions []models.PostRevision) error {
+		for i := range revisions {
+			rev := &revisions[i]
+			add(UploadUsage{Type: UploadUsageRevision, ID: rev.PostID, Title: rev.Title}, rev.Content)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	autosaves, err := dao.ListPostAutosaves()
+	if err != nil {
+		return nil, err
+	}
+	for i := range autosaves {
+		autosave := &autosaves[i]
+		add(UploadUsage{Type: UploadUsageAutosave, ID: autosave.PostID, Title: autosave.Title}, autosave.Content, autosave.CoverImage)
+	}
+
+	pages, err := dao.ListPages()
+	if err != nil {
+		return nil, err
+	}
+	for i := range pages {
+		page := &pages[i]
+		add(UploadUsage{Type: UploadUsagePage, ID: page.ID, Title: page.Title}, page.Content, page.OGImage)
+	}
+
+	moments, err := dao.ListMoments("", 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range moments {
+		moment := &moments[i]
+		add(UploadUsage{Type: UploadUsageMoment, ID: moment.ID, Title: momentTitle(moment)}, moment.Content, moment.Images...)
+	}
+
+	series, err := dao.ListSeries()
+	if err != nil {
+		return nil, err
+	}
+	for i := range series {
+		add(UploadUsage{Type: UploadUsageSeries, ID: series[i].ID, Title: series[i].Title}, "", series[i].CoverImage)
+	}
+
+	users, err := dao.ListAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		add(UploadUsage{Type: UploadUsageAvatar, ID: users[i].ID, Title: users[i].Username}, "", users[i].AvatarURL)
+	}
+	return index, nil
+}
+
+// normalizeUploadRef 单独存放的地址可能是上传接口返回的相对路径（uploads/...），补全为站内路径
+func normalizeUploadRef(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "uploads/") || strings.HasPrefix(raw, "./uploads/") {
+		return "/" + strings.TrimPrefix(raw, "./")
+	}
+	return raw
+}
+
+// References 返回引用指定文件的内容，path 可以是 /uploads/...、uploads/... 或本站完整地址
+func (idx UploadIndex) References(target string) ([]UploadUsage, error) {
+	_, p, ok := newLinkExtractor().classify(normalizeUploadRef(target))
+	if !ok || !strings.HasPrefix(p, "/uploads/") {
+		return nil, ErrInvalidUploadPath
+	}
+	usages := idx[p]
+	if usages == nil {
+		usages = []UploadUsage{}
+	}
+	return usages, nil
+}
+
+// GetUploadReferences 查询单个上传文件被哪些内容引用
+func GetUploadReferences(target string) ([]UploadUsage, error) {
+	index, err := BuildUploadIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index.References(target)
+}
+
+// ListUploadFiles 遍历图片与附件目录（压缩临时文件、图片备份等不参与统计），附带每个文件的引用
+func ListUploadFiles(index UploadIndex, now time.Time) ([]UploadFile, error) {
+	var files []UploadFile
+	for _, dir := range []string{media.ImageUploadDir, media.FileUploadDir} {
+		err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && p == dir {
+					return nil
+				}
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(media.UploadDir, p)
+			if err != nil {
+				return err
+			}
+			sitePath := path.Join(media.PublicURL, filepath.ToSlash(rel))
+			files = append(files, UploadFile{
+				Path:       sitePath,
+				URL:        media.GetFullFileURL(sitePath),
+				Size:       info.Size(),
+				ModifiedAt: info.ModTime(),
+				AgeDays:    int(now.Sub(info.ModTime()).Hours() / 24),
+				References: index[sitePath],
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// ListOrphanUploads 列出没有任何引用、且最后修改时间早于 minAge 的上传文件（minAge 用于排除刚上传、尚未保存到内容中的文件）
+func ListOrphanUploads(minAge time.Duration) ([]UploadFile, error) {
+	index, err := BuildUploadIndex()
+	if err != nil {
+		return nil, err
+	}
+	return orphanUploads(index, minAge, time.Now())
+}
+
+func orphanUploads(index UploadIndex, minAge time.Duration, now time.Time) ([]UploadFile, error) {
+	files, err := ListUploadFiles(index, now)
+	if err != nil {
+		return nil, err
+	}
+	orphans := make([]UploadFile, 0)
+	for _, file := range files {
+		if len(file.References) == 0 && now.Sub(file.ModifiedAt) >= minAge {
+			orphans = append(orphans, file)
+		}
+	}
+	return orphans, nil
+}
@@ -0,0 +1,868 @@
+package service
+
+import (
+	"api/internal/modules/content/dao"
+	"api/internal/modules/content/models"
+	"api/internal/modules/media"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// WXR 导入的对象类型，同时作为 import_mappings.kind
+const (
+	WXRKindCategory   = "category"
+	WXRKindTag        = "tag"
+	WXRKindAttachment = "attachment"
+	WXRKindPost       = "post"
+	WXRKindPage       = "page"
+	WXRKindComment    = "comment"
+
+	wxrDownloadTimeout   = 30 * time.Second
+	wxrMaxDownloadSize   = 50 << 20
+	wxrMaxDownloadRedirs = 5
+)
+
+var (
+	ErrWXRInvalid = errors.New("不是有效的 WordPress 导出文件（WXR）")
+
+	errWXRDownloadDenied = errors.New("附件地址不在允许下载的范围内")
+
+	// 正文中引用的 WordPress 上传文件，含缩略图尺寸后缀
+	wxrUploadRefPattern = regexp.MustCompile(`(?i)(?:https?:)?//[^\s"'<>()]+/wp-content/uploads/[^\s"'<>()]+`)
+	wxrSizeSuffix       = regexp.MustCompile(`-(?:\d+x\d+|scaled)(\.[A-Za-z0-9]+)$`)
+
+	wxrImageExts = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true, ".svg": true, ".bmp": true, ".avif": true}
+)
+
+// WXRImportOptions 导入参数
+type WXRImportOptions struct {
+	AuthorID   uint64 // 原作者在本站找不到同名用户时使用的作者
+	UploadsDir string // 本地的 wp-content/uploads 目录，附件优先从这里复制
+	Download   bool   // 本地找不到附件时从 attachment_url 下载，只在命令行工具中使用
+	// DownloadHosts 允许下载附件的主机；为空时只允许 WXR 中 base_site_url 的主机
+	DownloadHosts []string
+	DryRun        bool // 只生成报告，不写数据库、不复制文件
+}
+
+// WXRImportCounts 单类对象的导入数量
+type WXRImportCounts struct {
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// WXRImportReport 导入报告
+type WXRImportReport struct {
+	DryRun      bool            `json:"dry_run"`
+	Source      string          `json:"source"`
+	Categories  WXRImportCounts `json:"categories"`
+	Tags        WXRImportCounts `json:"tags"`
+	Attachments WXRImportCounts `json:"attachments"`
+	Posts       WXRImportCounts `json:"posts"`
+	Pages       WXRImportCounts `json:"pages"`
+	Comments    WXRImportCounts `json:"comments"`
+	Errors      []string        `json:"errors,omitempty"`
+}
+
+type wxrDocument struct {
+	Channel wxrChannel `xml:"channel"`
+}
+
+type wxrChannel struct {
+	Links       []string      `xml:"link"`
+	BaseSiteURL string        `xml:"base_site_url"`
+	BaseBlogURL string        `xml:"base_blog_url"`
+	Authors     []wxrAuthor   `xml:"author"`
+	Categories  []wxrCategory `xml:"category"`
+	Tags        []wxrTag      `xml:"tag"`
+	Items       []wxrItem     `xml:"item"`
+}
+
+type wxrAuthor struct {
+	Login string `xml:"author_login"`
+	Email string `xml:"author_email"`
+}
+
+type wxrCategory struct {
+	TermID      string `xml:"term_id"`
+	Slug        string `xml:"category_nicename"`
+	Parent      string `xml:"category_parent"` // 父分类的 slug
+	Name        string `xml:"cat_name"`
+	Description string `xml:"category_description"`
+}
+
+type wxrTag struct {
+	TermID      string `xml:"term_id"`
+	Slug        string `xml:"tag_slug"`
+	Name        string `xml:"tag_name"`
+	Description string `xml:"tag_description"`
+}
+
+type wxrItem struct {
+	Title         string        `xml:"title"`
+	Creator       string        `xml:"creator"`
+	Encoded       []wxrEncoded  `xml:"encoded"` // content:encoded 与 excerpt:encoded
+	PostID        string        `xml:"post_id"`
+	PostDate      string        `xml:"post_date"`
+	PostDateGMT   string        `xml:"post_date_gmt"`
+	CommentStatus string        `xml:"comment_status"`
+	PostName      string        `xml:"post_name"`
+	Status        string        `xml:"status"`
+	PostParent    string        `xml:"post_parent"`
+	MenuOrder     string        `xml:"menu_order"`
+	PostType      string        `xml:"post_type"`
+	PostPassword  string        `xml:"post_password"`
+	AttachmentURL string        `xml:"attachment_url"`
+	Terms         []wxrItemTerm `xml:"category"`
+	Meta          []wxrPostMeta `xml:"postmeta"`
+	Comments      []wxrComment  `xml:"comment"`
+}
+
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type wxrItemTerm struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type wxrPostMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+type wxrComment struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	AuthorURL   string `xml:"comment_author_url"`
+	AuthorIP    string `xml:"comment_author_IP"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      string `xml:"comment_parent"`
+}
+
+// encoded 按命名空间取 content:encoded 或 excerpt:encoded
+func (item *wxrItem) encoded(space string) string {
+	for _, e := range item.Encoded {
+		if strings.Contains(e.XMLName.Space, space) {
+			return strings.TrimSpace(e.Value)
+		}
+	}
+	return ""
+}
+
+func (item *wxrItem) meta(key string) string {
+	for _, m := range item.Meta {
+		if m.Key == key {
+			return strings.TrimSpace(m.Value)
+		}
+	}
+	return ""
+}
+
+// ImportWXR 导入 WordPress 导出的 WXR 文件：分类（含层级）、标签、附件、文章、页面与评论（含回复关系）。
+// 已导入的对象以「来源站点 + 类型 + 原站ID」记录在 import_mappings 中，重复导入时跳过。
+func ImportWXR(r io.Reader, opts WXRImportOptions) (*WXRImportReport, error) {
+	var doc wxrDocument
+	decoder := xml.NewDecoder(r)
+	decoder.Entity = xml.HTMLEntity
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWXRInvalid, err)
+	}
+	source := wxrSource(&doc.Channel)
+	if source == "" {
+		return nil, ErrWXRInvalid
+	}
+
+	if !opts.DryRun {
+		if _, err := dao.GetUserByID(opts.AuthorID); err != nil {
+			return nil, fmt.Errorf("作者用户 %d 不存在: %w", opts.AuthorID, err)
+		}
+	}
+	existing, err := dao.ListImportMappings(source)
+	if err != nil {
+		return nil, err
+	}
+
+	im := &wxrImporter{
+		opts:          opts,
+		source:        source,
+		downloadHosts: wxrDownloadHosts(&doc.Channel, opts.DownloadHosts),
+		report:        &WXRImportReport{DryRun: opts.DryRun, Source: source},
+		mappings:      make(map[string]*models.ImportMapping, len(existing)),
+		categories:    make(map[string]uint64),
+		tags:          make(map[string]uint64),
+		uploads:       make(map[string]string),
+		thumbnails:    make(map[string]string),
+		authors:       make(map[string]uint64),
+		authorEmails:  make(map[string]string),
+	}
+	for i := range existing {
+		im.mappings[existing[i].Kind+":"+existing[i].SourceID] = &existing[i]
+	}
+	for _, a := range doc.Channel.Authors {
+		im.authorEmails[strings.TrimSpace(a.Login)] = strings.TrimSpace(a.Email)
+	}
+
+	if err := im.importCategories(doc.Channel.Categories); err != nil {
+		return nil, err
+	}
+	if err := im.importTags(doc.Channel.Tags); err != nil {
+		return nil, err
+	}
+
+	var attachments, posts, pages []*wxrItem
+	for i := range doc.Channel.Items {
+		item := &doc.Channel.Items[i]
+		switch item.PostType {
+		case "attachment":
+			attachments = append(attachments, item)
+		case "post":
+			posts = append(posts, item)
+		case "page":
+			pages = append(pages, item)
+		}
+	}
+	for _, item := range attachments {
+		if err := im.importAttachment(item); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range posts {
+		if err := im.importPost(item); err != nil {
+			return nil, err
+		}
+	}
+	if err := im.importPages(pages); err != nil {
+		return nil, err
+	}
+	return im.report, nil
+}
+
+// wxrSource 来源站点地址，作为导入记录的键
+func wxrSource(ch *wxrChannel) string {
+	candidates := append([]string{ch.BaseBlogURL, ch.BaseSiteURL}, ch.Links...)
+	for _, candidate := range candidates {
+		if candidate = strings.TrimRight(strings.TrimSpace(candidate), "/"); candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// wxrDownloadHosts 允许下载附件的主机（小写、不含端口）
+func wxrDownloadHosts(ch *wxrChannel, configured []string) map[string]bool {
+	hosts := make(map[string]bool)
+	for _, host := range configured {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts[host] = true
+		}
+	}
+	if len(hosts) == 0 {
+		if u, err := url.Parse(strings.TrimSpace(ch.BaseSiteURL)); err == nil && u.Hostname() != "" {
+			hosts[strings.ToLower(u.Hostname())] = true
+		}
+	}
+	return hosts
+}
+
+type wxrImporter struct {
+	opts          WXRImportOptions
+	source        string
+	downloadHosts map[string]bool // 允许下载附件的主机
+	report        *WXRImportReport
+	mappings      map[string]*models.ImportMapping // kind:原站ID -> 导入记录
+	categories    map[string]uint64                // 分类 slug -> 本站ID
+	tags          map[string]uint64                // 标签 slug -> 本站ID
+	uploads       map[string]string                // 附件地址（去掉协议与尺寸后缀）-> 本站地址
+	thumbnails    map[string]string                // 附件原站ID -> 本站地址
+	authors       map[string]uint64                // 原作者登录名 -> 本站用户ID
+	authorEmails  map[string]string                // 原作者登录名 -> 邮箱
+}
+
+func (im *wxrImporter) mapping(kind, sourceID string) *models.ImportMapping {
+	return im.mappings[kind+":"+sourceID]
+}
+
+// remember 记录导入结果；试运行时只记在内存中
+func (im *wxrImporter) remember(kind, sourceID string, localID uint64, localURL string) error {
+	m := &models.ImportMapping{Source: im.source, Kind: kind, SourceID: sourceID, LocalID: localID, LocalURL: localURL}
+	if !im.opts.DryRun {
+		if err := dao.CreateImportMapping(m); err != nil {
+			return fmt.Errorf("保存导入记录 %s %s 失败: %w", kind, sourceID, err)
+		}
+	}
+	im.mappings[kind+":"+sourceID] = m
+	return nil
+}
+
+func (im *wxrImporter) fail(counts *WXRImportCounts, kind, sourceID string, err error) {
+	counts.Failed++
+	im.report.Errors = append(im.report.Errors, fmt.Sprintf("%s %s: %v", kind, sourceID, err))
+}
+
+// importCategories 先逐个导入，再按 category_parent 补上层级关系
+func (im *wxrImporter) importCategories(list []wxrCategory) error {
+	created := make(map[string]*models.Category)
+	for _, wc := range list {
+		slug := wxrUnescape(wc.Slug)
+		key := wxrTermKey(wc.TermID, slug)
+		if m := im.mapping(WXRKindCategory, key); m != nil {
+			im.categories[slug] = m.LocalID
+			im.report.Categories.Skipped++
+			continue
+		}
+		// 本站已有同 slug 的分类时直接关联
+		if existing, err := dao.GetCategoryBySlug(slug); err == nil {
+			im.categories[slug] = existing.ID
+			im.report.Categories.Skipped++
+			if err := im.remember(WXRKindCategory, key, existing.ID, ""); err != nil {
+				return err
+			}
+			continue
+		}
+
+		c := &models.Category{Name: strings.TrimSpace(wc.Name), Slug: slug, Description: strings.TrimSpace(wc.Description)}
+		if !im.opts.DryRun {
+			if err := CreateCategory(c); err != nil {
+				im.fail(&im.report.Categories, WXRKindCategory, key, err)
+				continue
+			}
+		}
+		if err := im.remember(WXRKindCategory, key, c.ID, ""); err != nil {
+			return err
+		}
+		im.categories[slug] = c.ID
+		created[slug] = c
+		im.report.Categories.Created++
+	}
+
+	if im.opts.DryRun {
+		return nil
+	}
+	for _, wc := range list {
+		c, ok := created[wxrUnescape(wc.Slug)]
+		parentID, hasParent := im.categories[wxrUnescape(wc.Parent)]
+		if !ok || wc.Parent == "" || !hasParent || parentID == c.ID {
+			continue
+		}
+		c.ParentID = &parentID
+		if err := UpdateCategory(c); err != nil {
+			im.fail(&im.report.Categories, WXRKindCategory, wxrTermKey(wc.TermID, wc.Slug), fmt.Errorf("设置父分类失败: %w", err))
+		}
+	}
+	return nil
+}
+
+func (im *wxrImporter) importTags(list []wxrTag) error {
+	for _, wt := range list {
+		slug := wxrUnescape(wt.Slug)
+		key := wxrTermKey(wt.TermID, slug)
+		if m := im.mapping(WXRKindTag, key); m != nil {
+			im.tags[slug] = m.LocalID
+			im.report.Tags.Skipped++
+			continue
+		}
+		if existing, err := dao.GetTagBySlug(slug); err == nil {
+			im.tags[slug] = existing.ID
+			im.report.Tags.Skipped++
+			if err := im.remember(WXRKindTag, key, existing.ID, ""); err != nil {
+				return err
+			}
+			continue
+		}
+
+		t := &models.Tag{Name: strings.TrimSpace(wt.Name), Slug: slug, Description: strings.TrimSpace(wt.Description)}
+		if !im.opts.DryRun {
+			if err := CreateTag(t); err != nil {
+				im.fail(&im.report.Tags, WXRKindTag, key, err)
+				continue
+			}
+		}
+		if err := im.remember(WXRKindTag, key, t.ID, ""); err != nil {
+			return err
+		}
+		im.tags[slug] = t.ID
+		im.report.Tags.Created++
+	}
+	return nil
+}
+
+// itemTermIDs 文章上的分类、标签；导出文件头部没有列出的项按 slug 补建
+func (im *wxrImporter) itemTermIDs(item *wxrItem) ([]uint64, []uint64) {
+	var categoryIDs, tagIDs []uint64
+	for _, term := range item.Terms {
+		slug := wxrUnescape(term.Nicename)
+		if slug == "" {
+			continue
+		}
+		switch term.Domain {
+		case "category":
+			if _, ok := im.categories[slug]; !ok {
+				_ = im.importCategories([]wxrCategory{{Slug: slug, Name: term.Name}})
+			}
+			if id := im.categories[slug]; id != 0 {
+				categoryIDs = append(categoryIDs, id)
+			}
+		case "post_tag":
+			if _, ok := im.tags[slug]; !ok {
+				_ = im.importTags([]wxrTag{{Slug: slug, Name: term.Name}})
+			}
+			if id := im.tags[slug]; id != 0 {
+				tagIDs = append(tagIDs, id)
+			}
+		}
+	}
+	return categoryIDs, tagIDs
+}
+
+// importAttachment 附件优先从本地 uploads 目录复制，其次按需下载
+func (im *wxrImporter) importAttachment(item *wxrItem) error {
+	ref := strings.TrimSpace(item.AttachmentURL)
+	if m := im.mapping(WXRKindAttachment, item.PostID); m != nil {
+		im.registerUpload(item.PostID, ref, m.LocalURL)
+		im.report.Attachments.Skipped++
+		return nil
+	}
+	if ref == "" {
+		im.report.Attachments.Skipped++
+		return nil
+	}
+
+	localURL, err := im.fetchAttachment(ref)
+	if err != nil {
+		im.fail(&im.report.Attachments, WXRKindAttachment, item.PostID, err)
+		return nil
+	}
+	if err := im.remember(WXRKindAttachment, item.PostID, 0, localURL); err != nil {
+		return err
+	}
+	im.registerUpload(item.PostID, ref, localURL)
+	im.report.Attachments.Created++
+	return nil
+}
+
+func (im *wxrImporter) registerUpload(postID, ref, localURL string) {
+	im.thumbnails[postID] = localURL
+	if ref != "" {
+		im.uploads[wxrUploadKey(ref)] = localURL
+	}
+}
+
+func (im *wxrImporter) fetchAttachment(ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(u.Path)
+	dir := media.FileUploadDir
+	if wxrImageExts[strings.ToLower(path.Ext(name))] {
+		dir = media.ImageUploadDir
+	}
+	dst := filepath.Join(dir, media.GenerateFileName(name))
+	localURL := media.GetFullFileURL(filepath.ToSlash(strings.TrimPrefix(dst, "./")))
+
+	var src string
+	if im.opts.UploadsDir != "" {
+		if i := strings.Index(u.Path, "/wp-content/uploads/"); i >= 0 {
+			candidate := filepath.Join(im.opts.UploadsDir, filepath.FromSlash(path.Clean(u.Path[i+len("/wp-content/uploads/"):])))
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				src = candidate
+			}
+		}
+	}
+	if src == "" && !im.opts.Download {
+		return "", fmt.Errorf("本地未找到附件 %s", ref)
+	}
+	if im.opts.DryRun {
+		return localURL, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	if src != "" {
+		return localURL, copyFile(src, dst)
+	}
+	return localURL, downloadFile(ref, dst, im.downloadHosts)
+}
+
+// checkDownloadURL 只允许从白名单主机通过 http(s) 下载
+func checkDownloadURL(u *url.URL, hosts map[string]bool) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || !hosts[strings.ToLower(u.Hostname())] {
+		return fmt.Errorf("%w: %s", errWXRDownloadDenied, u.Redacted())
+	}
+	return nil
+}
+
+// denyPrivateAddress 在 DNS 解析之后、建立连接之前拒绝内网、回环、链路本地等地址，
+// 防止白名单域名解析到内网或通过 DNS 重绑定访问内部服务
+func denyPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", errWXRDownloadDenied, host)
+	}
+	return nil
+}
+
+// newWXRDownloadClient 附件下载专用的 HTTP 客户端：不使用代理，重定向同样要求在白名单内
+func newWXRDownloadClient(hosts map[string]bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: denyPrivateAddress}
+	return &http.Client{
+		Timeout: wxrDownloadTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, addr)
+			},
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 15 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= wxrMaxDownloadRedirs {
+				return errors.New("重定向次数过多")
+			}
+			return checkDownloadURL(req.URL, hosts)
+		},
+	}
+}
+
+func downloadFile(ref, dst string, hosts map[string]bool) error {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return err
+	}
+	if err := checkDownloadURL(u, hosts); err != nil {
+		return err
+	}
+	resp, err := newWXRDownloadClient(hosts).Get(u.String())
+	if err != nil {
+		return fmt.Errorf("下载附件失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("下载附件失败: HTTP %d", resp.StatusCode)
+	}
+	if resp.ContentLength > wxrMaxDownloadSize {
+		return fmt.Errorf("附件超过 %d MB", wxrMaxDownloadSize>>20)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(out, io.LimitReader(resp.Body, wxrMaxDownloadSize+1))
+	if err == nil && n > wxrMaxDownloadSize {
+		err = fmt.Errorf("附件超过 %d MB", wxrMaxDownloadSize>>20)
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dst)
+	}
+	return err
+}
+
+// rewriteUploads 把正文中引用的附件（含缩略图尺寸）改写为本站地址
+func (im *wxrImporter) rewriteUploads(content string) string {
+	if len(im.uploads) == 0 {
+		return content
+	}
+	return wxrUploadRefPattern.ReplaceAllStringFunc(content, func(ref string) string {
+		if localURL, ok := im.uploads[wxrUploadKey(ref)]; ok {
+			return localURL
+		}
+		return ref
+	})
+}
+
+// wxrUploadKey 去掉协议与 -300x200、-scaled 等尺寸后缀，使缩略图指向同一附件
+func wxrUploadKey(ref string) string {
+	ref = strings.SplitN(ref, "?", 2)[0]
+	if i := strings.Index(ref, "//"); i >= 0 {
+		ref = ref[i+2:]
+	}
+	return strings.ToLower(wxrSizeSuffix.ReplaceAllString(ref, "$1"))
+}
+
+func (im *wxrImporter) importPost(item *wxrItem) error {
+	counts := &im.report.Posts
+	if item.Status == "trash" || item.Status == "auto-draft" || item.Status == "inherit" {
+		counts.Skipped++
+		return nil
+	}
+	if m := im.mapping(WXRKindPost, item.PostID); m != nil {
+		counts.Skipped++
+		return im.importComments(item, m.LocalID)
+	}
+
+	date := wxrTime(item.PostDateGMT, item.PostDate)
+	post := &models.Post{
+		Title:      strings.TrimSpace(item.Title),
+		Content:    im.rewriteUploads(item.encoded("content")),
+		Excerpt:    item.encoded("excerpt"),
+		CoverImage: im.thumbnails[item.meta("_thumbnail_id")],
+		AuthorID:   im.authorID(item.Creator),
+	}
+	post.Slug = im.uniqueSlug(wxrSlug(item), func(slug string) bool { return postSlugTaken(slug, 0) })
+	if item.CommentStatus == "open" || item.CommentStatus == "closed" {
+		post.CommentStatus = item.CommentStatus
+	}
+
+	visibility, password := VisibilityPublic, ""
+	switch {
+	case item.Status == "private":
+		visibility = VisibilityPrivate
+	case item.PostPassword != "":
+		visibility, password = VisibilityPassword, item.PostPassword
+	}
+	status := "published"
+	var publishAt *time.Time
+	switch item.Status {
+	case "draft", "pending":
+		status = item.Status
+	case "future":
+		if date != nil && date.After(time.Now()) {
+			status, publishAt = StatusScheduled, date
+		}
+	}
+
+	categoryIDs, tagIDs := im.itemTermIDs(item)
+	if !im.opts.DryRun {
+		if err := ApplyPostVisibility(post, visibility, password); err != nil {
+			im.fail(counts, WXRKindPost, item.PostID, err)
+			return nil
+		}
+		if err := ApplyPostStatus(post, status, publishAt); err != nil {
+			im.fail(counts, WXRKindPost, item.PostID, err)
+			return nil
+		}
+		if date != nil {
+			post.CreatedAt = *date
+			if status == "published" {
+				post.PublishedAt = date
+			}
+		}
+		if err := CreatePost(post, categoryIDs, tagIDs); err != nil {
+			im.fail(counts, WXRKindPost, item.PostID, err)
+			return nil
+		}
+	}
+	if err := im.remember(WXRKindPost, item.PostID, post.ID, ""); err != nil {
+		return err
+	}
+	counts.Created++
+	return im.importComments(item, post.ID)
+}
+
+// importPages 页面的父子关系在全部页面导入后补上
+func (im *wxrImporter) importPages(items []*wxrItem) error {
+	counts := &im.report.Pages
+	created := make(map[string]*models.Page)
+	for _, item := range items {
+		// 本站评论只关联文章，页面评论不导入
+		im.report.Comments.Skipped += len(item.Comments)
+		if item.Status == "trash" || item.Status == "auto-draft" || item.Status == "inherit" {
+			counts.Skipped++
+			continue
+		}
+		if im.mapping(WXRKindPage, item.PostID) != nil {
+			counts.Skipped++
+			continue
+		}
+
+		page := &models.Page{
+			Title:   strings.TrimSpace(item.Title),
+			Content: im.rewriteUploads(item.encoded("content")),
+			Excerpt: item.encoded("excerpt"),
+			Status:  "draft",
+		}
+		page.MenuOrder, _ = strconv.Atoi(strings.TrimSpace(item.MenuOrder))
+		page.Slug = im.uniqueSlug(wxrSlug(item), dao.PageSlugExists)
+		if item.Status == "publish" && item.PostPassword == "" {
+			page.Status = "published"
+		}
+		if date := wxrTime(item.PostDateGMT, item.PostDate); date != nil {
+			page.CreatedAt = *date
+		}
+		if !im.opts.DryRun {
+			if err := CreatePage(page); err != nil {
+				im.fail(counts, WXRKindPage, item.PostID, err)
+				continue
+			}
+		}
+		if err := im.remember(WXRKindPage, item.PostID, page.ID, ""); err != nil {
+			return err
+		}
+		created[item.PostID] = page
+		counts.Created++
+	}
+
+	if im.opts.DryRun {
+		return nil
+	}
+	for _, item := range items {
+		page, ok := created[item.PostID]
+		parent := im.mapping(WXRKindPage, item.PostParent)
+		if !ok || parent == nil || parent.LocalID == page.ID {
+			continue
+		}
+		page.ParentID = &parent.LocalID
+		if err := UpdatePage(page); err != nil {
+			im.fail(counts, WXRKindPage, item.PostID, fmt.Errorf("设置父页面失败: %w", err))
+		}
+	}
+	return nil
+}
+
+// importComments 按原站ID升序导入，保证父评论先于回复；pingback、trackback 不导入
+func (im *wxrImporter) importComments(item *wxrItem, postID uint64) error {
+	comments := append([]wxrComment(nil), item.Comments...)
+	sort.SliceStable(comments, func(i, j int) bool {
+		a, _ := strconv.ParseUint(comments[i].ID, 10, 64)
+		b, _ := strconv.ParseUint(comments[j].ID, 10, 64)
+		return a < b
+	})
+
+	counts := &im.report.Comments
+	for _, wc := range comments {
+		if wc.Type == "pingback" || wc.Type == "trackback" || im.mapping(WXRKindComment, wc.ID) != nil {
+			counts.Skipped++
+			continue
+		}
+
+		comment := &models.Comment{
+			Content:     wc.Content,
+			AuthorName:  strings.TrimSpace(wc.Author),
+			AuthorEmail: strings.TrimSpace(wc.AuthorEmail),
+			AuthorURL:   strings.TrimSpace(wc.AuthorURL),
+			AuthorIP:    strings.TrimSpace(wc.AuthorIP),
+			PostID:      postID,
+			Status:      wxrCommentStatus(wc.Approved),
+		}
+		if parent := im.mapping(WXRKindComment, wc.Parent); parent != nil && wc.Parent != "0" {
+			comment.ParentID = &parent.LocalID
+		}
+		if date := wxrTime(wc.DateGMT, wc.Date); date != nil {
+			comment.CreatedAt = *date
+		}
+		if !im.opts.DryRun {
+			if err := CreateComment(comment); err != nil {
+				im.fail(counts, WXRKindComment, wc.ID, err)
+				continue
+			}
+		}
+		if err := im.remember(WXRKindComment, wc.ID, comment.ID, ""); err != nil {
+			return err
+		}
+		counts.Created++
+	}
+	return nil
+}
+
+// authorID 按登录名或邮箱匹配本站用户，找不到时使用默认作者
+func (im *wxrImporter) authorID(login string) uint64 {
+	if id, ok := im.authors[login]; ok {
+		return id
+	}
+	id := im.opts.AuthorID
+	if login != "" {
+		if user, err := dao.GetUserByUsernameOrEmail(login, ""); err == nil {
+			id = user.ID
+		} else if email := im.authorEmails[login]; email != "" {
+			if user, err := dao.GetUserByUsernameOrEmail("", email); err == nil {
+				id = user.ID
+			}
+		}
+	}
+	im.authors[login] = id
+	return id
+}
+
+// uniqueSlug slug 被本站其他内容占用时追加数字后缀
+func (im *wxrImporter) uniqueSlug(base string, taken func(string) bool) string {
+	slug := base
+	for i := 2; taken(slug); i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+	return slug
+}
+
+// wxrSlug 原站 post_name（可能是百分号编码的中文），草稿没有 post_name 时由标题生成
+func wxrSlug(item *wxrItem) string {
+	if slug := wxrUnescape(item.PostName); slug != "" {
+		return slug
+	}
+	if slug := importSlug(item.Title); slug != "" {
+		return slug
+	}
+	return "wp-" + item.PostID
+}
+
+func wxrUnescape(s string) string {
+	s = strings.TrimSpace(s)
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
+}
+
+// wxrTermKey 分类、标签的导入记录键；旧版 WXR 没有 term_id 时使用 slug
+func wxrTermKey(termID, slug string) string {
+	if termID = strings.TrimSpace(termID); termID != "" {
+		return termID
+	}
+	return "slug:" + wxrUnescape(slug)
+}
+
+// wxrTime 优先使用 GMT 时间；草稿的时间为 0000-00-00 00:00:00
+func wxrTime(gmt, local string) *time.Time {
+	const layout = "2006-01-02 15:04:05"
+	if t, err := time.ParseInLocation(layout, strings.TrimSpace(gmt), time.UTC); err == nil && t.Year() > 1 {
+		t = t.In(time.Local)
+		return &t
+	}
+	if t, err := time.ParseInLocation(layout, strings.TrimSpace(local), time.Local); err == nil && t.Year() > 1 {
+		return &t
+	}
+	return nil
+}
+
+func wxrCommentStatus(approved string) string {
+	switch approved {
+	case "1":
+		return "approved"
+	case "spam":
+		return "spam"
+	case "trash":
+		return "trash"
+	default:
+		return "pending"
+	}
+}
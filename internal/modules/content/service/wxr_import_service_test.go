@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestCheckDownloadURL(t *testing.T) {
+	hosts := wxrDownloadHosts(&wxrChannel{BaseSiteURL: "https://Blog.example.com:8443"}, nil)
+	cases := []struct {
+		raw string
+		ok  bool
+	}{
+		{"https://blog.example.com/wp-content/uploads/a.png", true},
+		{"http://BLOG.example.com:8080/wp-content/uploads/a.png", true},
+		{"https://cdn.example.com/wp-content/uploads/a.png", false},
+		{"ftp://blog.example.com/a.png", false},
+		{"file:///etc/passwd", false},
+		{"http://127.0.0.1/a.png", false},
+	}
+	for _, tc := range cases {
+		u, _ := url.Parse(tc.raw)
+		if err := checkDownloadURL(u, hosts); (err == nil) != tc.ok {
+			t.Errorf("checkDownloadURL(%q) = %v, want ok=%v", tc.raw, err, tc.ok)
+		}
+	}
+
+	configured := wxrDownloadHosts(&wxrChannel{BaseSiteURL: "https://blog.example.com"}, []string{" CDN.example.com "})
+	if !configured["cdn.example.com"] || configured["blog.example.com"] {
+		t.Fatalf("configured hosts = %v", configured)
+	}
+}
+
+func TestDenyPrivateAddress(t *testing.T) {
+	cases := []struct {
+		address string
+		ok      bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"10.0.0.5:80", false},
+		{"172.16.3.4:80", false},
+		{"192.168.1.1:80", false},
+		{"169.254.169.254:80", false},
+		{"0.0.0.0:80", false},
+		{"[::1]:80", false},
+		{"[fd00::1]:80", false},
+		{"[fe80::1]:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+	}
+	for _, tc := range cases {
+		err := denyPrivateAddress("tcp", tc.address, nil)
+		if (err == nil) != tc.ok {
+			t.Errorf("denyPrivateAddress(%q) = %v, want ok=%v", tc.address, err, tc.ok)
+		}
+		if err != nil && !errors.Is(err, errWXRDownloadDenied) {
+			t.Errorf("denyPrivateAddress(%q) error = %v", tc.address, err)
+		}
+	}
+}